@@ -0,0 +1,36 @@
+//go:build linux
+
+package main
+
+import (
+	"errors"
+	"runtime"
+
+	"golang.org/x/sys/unix"
+)
+
+// pinToCPU locks the calling goroutine to its OS thread and restricts that
+// thread to logical CPU cpu, so CPUID reports that CPU. The returned
+// function restores the thread's affinity and unlocks it.
+func pinToCPU(cpu int) (func(), error) {
+	var previous, pinned unix.CPUSet
+	if cpu < 0 || cpu >= len(pinned)*64 {
+		return nil, errors.New("no such CPU")
+	}
+
+	runtime.LockOSThread()
+	if err := unix.SchedGetaffinity(0, &previous); err != nil {
+		runtime.UnlockOSThread()
+		return nil, err
+	}
+	pinned.Set(cpu)
+	if err := unix.SchedSetaffinity(0, &pinned); err != nil {
+		runtime.UnlockOSThread()
+		return nil, err
+	}
+
+	return func() {
+		unix.SchedSetaffinity(0, &previous)
+		runtime.UnlockOSThread()
+	}, nil
+}
@@ -0,0 +1,9 @@
+//go:build !linux
+
+package main
+
+// pinToCPU is only implemented on Linux. Elsewhere CPUID is read on
+// whichever CPU the thread runs on, as without --cpu.
+func pinToCPU(cpu int) (func(), error) {
+	return func() {}, nil
+}
@@ -0,0 +1,18 @@
+package main
+
+import (
+	"encoding/binary"
+	"runtime"
+	"unsafe"
+)
+
+// collectArchInfo describes the architecture this binary was built for,
+// which under emulation (an amd64 binary on Rosetta or qemu-user) differs
+// from the machine the CPU pages otherwise describe.
+func collectArchInfo() (goArch string, pointerBits int, byteOrder string) {
+	byteOrder = "big-endian"
+	if binary.NativeEndian.Uint16([]byte{1, 0}) == 1 {
+		byteOrder = "little-endian"
+	}
+	return runtime.GOARCH, int(unsafe.Sizeof(uintptr(0))) * 8, byteOrder
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const powerSupplyPath = "/sys/class/power_supply"
+
+// collectBatteryInfo reads the system batteries from the power_supply class.
+// Batteries of peripherals such as wireless mice (scope "Device") are left
+// out. Machines without a battery, and other platforms, get an empty list.
+func collectBatteryInfo() *BatteryInfo {
+	info := &BatteryInfo{}
+	supplies, _ := filepath.Glob(filepath.Join(powerSupplyPath, "*"))
+	for _, dir := range supplies {
+		if readSysfsString(filepath.Join(dir, "type")) != "Battery" ||
+			readSysfsString(filepath.Join(dir, "scope")) == "Device" ||
+			readSysfsString(filepath.Join(dir, "present")) == "0" {
+			continue
+		}
+
+		battery := BatteryDevice{
+			Name:         filepath.Base(dir),
+			Manufacturer: readSysfsString(filepath.Join(dir, "manufacturer")),
+			Model:        readSysfsString(filepath.Join(dir, "model_name")),
+			State:        strings.ToLower(readSysfsString(filepath.Join(dir, "status"))),
+		}
+		battery.ChargePercent, _ = strconv.Atoi(readSysfsString(filepath.Join(dir, "capacity")))
+		battery.CycleCount, _ = strconv.Atoi(readSysfsString(filepath.Join(dir, "cycle_count")))
+
+		// Capacities are in µWh (energy_*) or, on some firmware, µAh (charge_*)
+		for _, source := range []struct{ prefix, unit string }{{"energy", "Wh"}, {"charge", "Ah"}} {
+			full, errFull := strconv.ParseFloat(readSysfsString(filepath.Join(dir, source.prefix+"_full")), 64)
+			design, errDesign := strconv.ParseFloat(readSysfsString(filepath.Join(dir, source.prefix+"_full_design")), 64)
+			if errFull == nil && errDesign == nil {
+				battery.FullCapacity = full / 1e6
+				battery.DesignCapacity = design / 1e6
+				battery.CapacityUnit = source.unit
+				break
+			}
+		}
+
+		info.Batteries = append(info.Batteries, battery)
+	}
+	return info
+}
+
+// healthPercent is the full-charge capacity as a share of the design
+// capacity, or 0 when either is unknown.
+func (b *BatteryDevice) healthPercent() float64 {
+	if b.DesignCapacity == 0 {
+		return 0
+	}
+	return b.FullCapacity * 100 / b.DesignCapacity
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+)
+
+var categoriesJSON bool
+
+var categoriesCmd = &cobra.Command{
+	Use:   "categories",
+	Short: "Print the CPU feature categories",
+	Long: "Prints each feature category with its count of supported features, or with --json the categories mapped to their features' " +
+		"names, vendors and descriptions, without the rest of the hardware report.",
+	Args: cobra.NoArgs,
+	Run:  runCategories,
+}
+
+func init() {
+	categoriesCmd.Flags().BoolVar(&categoriesJSON, "json", false, "Print the category to features map as JSON")
+	rootCmd.AddCommand(categoriesCmd)
+}
+
+func runCategories(cmd *cobra.Command, args []string) {
+	categories := collectForExport().CPU.FeatureCategories
+
+	err := writeOutput(outputPath, func(w io.Writer) error {
+		if categoriesJSON {
+			return writeCategoriesJSON(w, categories)
+		}
+		return writeCategoriesList(w, categories)
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing categories: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// writeCategoriesJSON writes the categories as a JSON object. encoding/json
+// sorts the category keys and each category's features are sorted by name,
+// since cpuid lists them in no fixed order, so runs can be compared.
+func writeCategoriesJSON(w io.Writer, categories map[string][]FeatureDetail) error {
+	sorted := make(map[string][]FeatureDetail, len(categories))
+	for name, features := range categories {
+		features = slices.Clone(features)
+		sort.Slice(features, func(i, j int) bool { return features[i].Name < features[j].Name })
+		sorted[name] = features
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(sorted)
+}
+
+// writeCategoriesList writes each category and its feature count in sorted
+// category order.
+func writeCategoriesList(w io.Writer, categories map[string][]FeatureDetail) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, name := range sortedCategoryNames(categories) {
+		fmt.Fprintf(tw, "%s\t%d\n", name, len(categories[name]))
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// fileConfig holds the flag defaults a config file can set. Fields are
+// pointers so a setting left out of the file is told apart from a zero one.
+type fileConfig struct {
+	Theme          *string `yaml:"theme"`
+	Refresh        *int    `yaml:"refresh"`
+	ASCII          *bool   `yaml:"ascii"`
+	FeatureColumns *int    `yaml:"feature-columns"`
+}
+
+// configPath returns where the config file is read from:
+// $XDG_CONFIG_HOME/earhw/config.yaml, falling back to the platform's user
+// config directory.
+func configPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "earhw", "config.yaml"), nil
+}
+
+// loadConfig applies the config file's settings as defaults for the flags
+// not given on the command line.
+func loadConfig(cmd *cobra.Command, args []string) error {
+	path, err := configPath()
+	if err != nil {
+		return nil // No home directory to look in
+	}
+	if err := applyConfigFile(cmd, path); err != nil {
+		cmd.SilenceUsage = true // The command line itself was fine
+		return fmt.Errorf("reading config %s: %w", path, err)
+	}
+	return nil
+}
+
+// applyConfigFile sets the flags of cmd that path configures, unless they
+// were given on the command line. A missing file is not an error.
+func applyConfigFile(cmd *cobra.Command, path string) error {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var cfg fileConfig
+	decoder := yaml.NewDecoder(f)
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&cfg); err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+
+	settings := map[string]string{}
+	if cfg.Theme != nil {
+		settings["theme"] = *cfg.Theme
+	}
+	// A snapshot shown with --from can't be refreshed
+	if cfg.Refresh != nil && !cmd.Flags().Changed("from") {
+		settings["refresh"] = strconv.Itoa(*cfg.Refresh)
+	}
+	if cfg.ASCII != nil {
+		settings["ascii"] = strconv.FormatBool(*cfg.ASCII)
+	}
+	if cfg.FeatureColumns != nil {
+		settings["feature-columns"] = strconv.Itoa(*cfg.FeatureColumns)
+	}
+
+	for name, value := range settings {
+		flag := cmd.Flags().Lookup(name)
+		if flag == nil || flag.Changed {
+			continue // Another command's flag, or overridden on the command line
+		}
+		// Setting the value directly leaves the flag unmarked as changed
+		if err := flag.Value.Set(value); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+)
+
+var coresTable bool
+
+var coresCmd = &cobra.Command{
+	Use:   "cores",
+	Short: "Print per-CPU clock speeds and temperatures",
+	Long: "Prints one line per logical CPU with its package ID, core ID, thread ID within the core, current clock speed and core temperature, " +
+		"sorted by package, core and then thread. Readings the platform doesn't report are left out, or shown as - in the table.",
+	Args: cobra.NoArgs,
+	Run:  runCores,
+}
+
+func init() {
+	coresCmd.Flags().BoolVar(&coresTable, "table", false, "Print an aligned table with a header row")
+	rootCmd.AddCommand(coresCmd)
+}
+
+// logicalCPU places one logical CPU in the core topology.
+type logicalCPU struct {
+	cpu    int
+	pkg    int // Physical package (socket) ID
+	core   int // Core ID, unique only within pkg
+	thread int // Position among the core's sibling threads
+}
+
+func runCores(cmd *cobra.Command, args []string) {
+	hwInfo := collectForExport()
+	cpus := collectLogicalCPUs(hwInfo.CPU.ProcessorInfo)
+	temps := collectCoreTempsByID()
+	mhz := collectMHzByCPU()
+
+	var err error
+	if coresTable {
+		err = writeCoresTable(os.Stdout, cpus, mhz, temps)
+	} else {
+		err = writeCoresList(os.Stdout, cpus, mhz, temps)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing cores: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// writeCoresList writes one key=value line per logical CPU.
+func writeCoresList(w io.Writer, cpus []logicalCPU, mhz map[int]uint32, temps map[coreKey]float64) error {
+	var b strings.Builder
+	for _, c := range cpus {
+		fmt.Fprintf(&b, "cpu=%d package=%d core=%d thread=%d", c.cpu, c.pkg, c.core, c.thread)
+		if cpuMHz, ok := mhz[c.cpu]; ok {
+			fmt.Fprintf(&b, " mhz=%d", cpuMHz)
+		}
+		if temp, ok := temps[coreKey{pkg: c.pkg, core: c.core}]; ok {
+			fmt.Fprintf(&b, " temp_c=%.1f", temp)
+		}
+		b.WriteString("\n")
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// writeCoresTable writes the logical CPUs as a table aligned with tabwriter.
+func writeCoresTable(w io.Writer, cpus []logicalCPU, mhz map[int]uint32, temps map[coreKey]float64) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "CPU\tPACKAGE\tCORE\tTHREAD\tMHZ\tTEMP (C)")
+	for _, c := range cpus {
+		cpuMHz, temp := "-", "-"
+		if f, ok := mhz[c.cpu]; ok {
+			cpuMHz = strconv.FormatUint(uint64(f), 10)
+		}
+		if t, ok := temps[coreKey{pkg: c.pkg, core: c.core}]; ok {
+			temp = fmt.Sprintf("%.1f", t)
+		}
+		fmt.Fprintf(tw, "%d\t%d\t%d\t%d\t%s\t%s\n", c.cpu, c.pkg, c.core, c.thread, cpuMHz, temp)
+	}
+	return tw.Flush()
+}
+
+// collectLogicalCPUs reads each logical CPU's package and core IDs and its
+// position among the core's sibling threads from sysfs, sorted by package,
+// core and then thread.
+// Where sysfs isn't available it falls back to CPUID's core and thread
+// counts, numbered as on the CPU page's topology diagram.
+func collectLogicalCPUs(info ProcessorInfoDetail) []logicalCPU {
+	var cpus []logicalCPU
+	dirs, _ := filepath.Glob("/sys/devices/system/cpu/cpu[0-9]*")
+	for _, dir := range dirs {
+		cpu, err := strconv.Atoi(strings.TrimPrefix(filepath.Base(dir), "cpu"))
+		if err != nil {
+			continue
+		}
+		core, err := strconv.Atoi(readSysfsString(filepath.Join(dir, "topology", "core_id")))
+		if err != nil {
+			continue // Offline CPUs have no topology
+		}
+		pkg, _ := strconv.Atoi(readSysfsString(filepath.Join(dir, "topology", "physical_package_id")))
+		thread := 0
+		for i, sibling := range parseCPUList(readSysfsString(filepath.Join(dir, "topology", "thread_siblings_list"))) {
+			if sibling == cpu {
+				thread = i
+			}
+		}
+		cpus = append(cpus, logicalCPU{cpu: cpu, pkg: pkg, core: core, thread: thread})
+	}
+
+	if len(cpus) == 0 {
+		threadsPerCore := max(int(info.ThreadPerCore), 1)
+		for core := 0; core < int(info.CoreCount); core++ {
+			for t := 0; t < threadsPerCore; t++ {
+				cpus = append(cpus, logicalCPU{cpu: core*threadsPerCore + t, core: core, thread: t})
+			}
+		}
+	}
+
+	sort.Slice(cpus, func(i, j int) bool {
+		if cpus[i].pkg != cpus[j].pkg {
+			return cpus[i].pkg < cpus[j].pkg
+		}
+		if cpus[i].core != cpus[j].core {
+			return cpus[i].core < cpus[j].core
+		}
+		if cpus[i].thread != cpus[j].thread {
+			return cpus[i].thread < cpus[j].thread
+		}
+		return cpus[i].cpu < cpus[j].cpu
+	})
+	return cpus
+}
+
+// parseCPUList expands a sysfs CPU list such as "0-3,8" into CPU numbers.
+func parseCPUList(list string) []int {
+	var cpus []int
+	for _, part := range strings.Split(list, ",") {
+		first, last, isRange := strings.Cut(part, "-")
+		start, err := strconv.Atoi(first)
+		if err != nil {
+			continue
+		}
+		end := start
+		if isRange {
+			if end, err = strconv.Atoi(last); err != nil {
+				continue
+			}
+		}
+		for cpu := start; cpu <= end; cpu++ {
+			cpus = append(cpus, cpu)
+		}
+	}
+	return cpus
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWriteCoresListMultiSocket(t *testing.T) {
+	cpus := []logicalCPU{
+		{cpu: 0, pkg: 0, core: 0},
+		{cpu: 1, pkg: 1, core: 0},
+	}
+	temps := map[coreKey]float64{
+		{pkg: 0, core: 0}: 40,
+		{pkg: 1, core: 0}: 60,
+	}
+
+	var b strings.Builder
+	if err := writeCoresList(&b, cpus, nil, temps); err != nil {
+		t.Fatal(err)
+	}
+	want := "cpu=0 package=0 core=0 thread=0 temp_c=40.0\n" +
+		"cpu=1 package=1 core=0 thread=0 temp_c=60.0\n"
+	if b.String() != want {
+		t.Errorf("writeCoresList() =\n%s\nwant\n%s", b.String(), want)
+	}
+}
+
+func TestCoresMHzWithOfflineCPU(t *testing.T) {
+	// CPU 1 is offline, so /proc/cpuinfo skips from processor 0 to 2
+	cpuinfo := "processor\t: 0\ncpu MHz\t\t: 1200.000\n\n" +
+		"processor\t: 2\ncpu MHz\t\t: 3400.400\n\n" +
+		"processor\t: 3\ncpu MHz\t\t: 2999.600\n"
+	mhz := parseCPUInfoMHz(strings.NewReader(cpuinfo))
+
+	cpus := []logicalCPU{
+		{cpu: 0, core: 0},
+		{cpu: 2, core: 2},
+		{cpu: 3, core: 3},
+	}
+	var b strings.Builder
+	if err := writeCoresList(&b, cpus, mhz, nil); err != nil {
+		t.Fatal(err)
+	}
+	want := "cpu=0 package=0 core=0 thread=0 mhz=1200\n" +
+		"cpu=2 package=0 core=2 thread=0 mhz=3400\n" +
+		"cpu=3 package=0 core=3 thread=0 mhz=3000\n"
+	if b.String() != want {
+		t.Errorf("writeCoresList() =\n%s\nwant\n%s", b.String(), want)
+	}
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/earentir/cpuid"
+)
+
+// collectBaseMHz returns the nominal base frequency, preferring CPUID leaf
+// 0x16 and falling back to cpufreq's base_frequency on Linux. It returns 0
+// when neither source reports a value (common under virtualization).
+func collectBaseMHz(maxFunc uint32) uint32 {
+	if maxFunc >= 0x16 {
+		eax, _, _, _ := cpuid.CPUIDWithMode(0x16, 0, false, "")
+		if base := eax & 0xFFFF; base != 0 {
+			return base
+		}
+	}
+
+	data, err := os.ReadFile("/sys/devices/system/cpu/cpu0/cpufreq/base_frequency")
+	if err != nil {
+		return 0
+	}
+	khz, err := strconv.ParseUint(strings.TrimSpace(string(data)), 10, 32)
+	if err != nil {
+		return 0
+	}
+	return uint32(khz / 1000)
+}
+
+// collectCurrentMHz reads the current frequency of every online logical
+// CPU from /proc/cpuinfo, in processor order.
+func collectCurrentMHz() []uint32 {
+	byCPU := collectMHzByCPU()
+	cpus := make([]int, 0, len(byCPU))
+	for cpu := range byCPU {
+		cpus = append(cpus, cpu)
+	}
+	sort.Ints(cpus)
+
+	freqs := make([]uint32, 0, len(cpus))
+	for _, cpu := range cpus {
+		freqs = append(freqs, byCPU[cpu])
+	}
+	return freqs
+}
+
+// collectMHzByCPU reads the current frequency of every online logical CPU
+// from /proc/cpuinfo, keyed by processor number.
+func collectMHzByCPU() map[int]uint32 {
+	file, err := os.Open("/proc/cpuinfo")
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+	return parseCPUInfoMHz(file)
+}
+
+// parseCPUInfoMHz maps each "processor" block's number to its "cpu MHz".
+// Offline CPUs have no block, so the numbers can have gaps.
+func parseCPUInfoMHz(r io.Reader) map[int]uint32 {
+	freqs := make(map[int]uint32)
+	processor := -1
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), ":")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch strings.TrimSpace(key) {
+		case "processor":
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				n = -1
+			}
+			processor = n
+		case "cpu MHz":
+			mhz, err := strconv.ParseFloat(value, 64)
+			if err != nil || processor < 0 {
+				continue
+			}
+			freqs[processor] = uint32(mhz + 0.5)
+		}
+	}
+	return freqs
+}
+
+// mhzStats returns the minimum, maximum and average of the given frequencies.
+func mhzStats(freqs []uint32) (minMHz, maxMHz, avgMHz uint32) {
+	if len(freqs) == 0 {
+		return 0, 0, 0
+	}
+	minMHz, maxMHz = freqs[0], freqs[0]
+	var total uint64
+	for _, f := range freqs {
+		minMHz = min(minMHz, f)
+		maxMHz = max(maxMHz, f)
+		total += uint64(f)
+	}
+	return minMHz, maxMHz, uint32(total / uint64(len(freqs)))
+}
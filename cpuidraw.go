@@ -0,0 +1,58 @@
+package main
+
+import "github.com/earentir/cpuid"
+
+// maxCPUIDSubleaves bounds the subleaf walk, in case a hypervisor reports
+// leaves that never terminate.
+const maxCPUIDSubleaves = 64
+
+// collectRawCPUID queries every standard leaf up to maxFunc and extended
+// leaf up to maxExtFunc. Leaves with subleaves are walked until the
+// subleaf that marks their end, as cpuid.CaptureData does.
+func collectRawCPUID(maxFunc, maxExtFunc uint32) []CPUIDLeaf {
+	leaves := []CPUIDLeaf{}
+	query := func(leaf uint32) {
+		for subleaf := uint32(0); subleaf < maxCPUIDSubleaves; subleaf++ {
+			a, b, c, d := cpuid.CPUIDWithMode(leaf, subleaf, false, "")
+			if subleaf > 0 && cpuidSubleafEnd(leaf, subleaf, a, b, c, d) {
+				break
+			}
+			leaves = append(leaves, CPUIDLeaf{Leaf: leaf, Subleaf: subleaf, EAX: a, EBX: b, ECX: c, EDX: d})
+			if !cpuidHasSubleaves(leaf) {
+				break
+			}
+		}
+	}
+
+	for leaf := uint32(0); leaf <= maxFunc && leaf < 0x100; leaf++ {
+		query(leaf)
+	}
+	if maxExtFunc >= 0x80000000 {
+		for leaf := uint32(0x80000000); leaf <= maxExtFunc && leaf < 0x80000100; leaf++ {
+			query(leaf)
+		}
+	}
+	return leaves
+}
+
+// cpuidHasSubleaves reports whether leaf returns different data per ECX
+// subleaf.
+func cpuidHasSubleaves(leaf uint32) bool {
+	switch leaf {
+	case 0x4, 0x7, 0xB, 0xD, 0xF, 0x10, 0x12, 0x14, 0x17, 0x18, 0x1D, 0x1F, 0x8000001D, 0x80000020:
+		return true
+	}
+	return false
+}
+
+// cpuidSubleafEnd reports whether a subleaf past the first is the end of
+// its leaf's list.
+func cpuidSubleafEnd(leaf, subleaf, a, b, c, d uint32) bool {
+	switch leaf {
+	case 0x4, 0x8000001D:
+		return a&0x1F == 0 // Cache type "null"
+	case 0xB, 0x1F:
+		return c&0xFF00 == 0 // Level type "invalid"
+	}
+	return a == 0 && b == 0 && c == 0 && d == 0
+}
@@ -2,49 +2,69 @@ package main
 
 import (
 	"fmt"
+	"runtime"
+	"strings"
 
 	"github.com/earentir/cpuid"
 )
 
-func collectCPUInfo() (*CPUInfo, error) {
+// collectCPUInfo reads the CPU through CPUID. The parts that can fail on
+// their own, such as the cache and TLB descriptors, are left empty and
+// their errors returned in partErrs; only err is fatal.
+func collectCPUInfo() (info *CPUInfo, partErrs []error, err error) {
+	// With --cpu, every CPUID query below runs on that logical CPU
+	if pinnedCPU != -1 {
+		unpin, err := pinToCPU(pinnedCPU)
+		if err != nil {
+			return nil, nil, fmt.Errorf("can't run on CPU %d: %w", pinnedCPU, err)
+		}
+		defer unpin()
+	}
+
 	// Use cpuid package to collect ALL available information
+	done := profileStep("CPU identification")
 	maxFunc, maxExtFunc := cpuid.GetMaxFunctions(false, "")
 	vendorID := cpuid.GetVendorID(false, "")
 	vendorName := cpuid.GetVendorName(false, "")
 	brandString := cpuid.GetBrandString(maxExtFunc, false, "")
 	modelData := cpuid.GetModelData(false, "")
 	processorInfo := cpuid.GetProcessorInfo(maxFunc, maxExtFunc, false, "")
+	rawCPUID := collectRawCPUID(maxFunc, maxExtFunc)
+	done()
 
 	// Get ALL supported features with detailed information
+	done = profileStep("CPU features")
 	supportedFeatures := []string{}
 	featureCategories := make(map[string][]FeatureDetail)
 	categories := cpuid.GetAllFeatureCategories()
-	detailedFeatures := cpuid.GetAllFeatureCategoriesDetailed()
+	detailsByName := featureDetailIndex(convertFeatureDetails(cpuid.GetAllFeatureCategoriesDetailed()))
 
+	supported := make(map[string][]string, len(categories))
 	for _, category := range categories {
-		features := cpuid.GetSupportedFeatures(category, false, "")
-		supportedFeatures = append(supportedFeatures, features...)
-
-		// Get detailed feature information
-		if categoryDetails, ok := detailedFeatures[category]; ok {
-			featureDetails := []FeatureDetail{}
-			for _, feat := range categoryDetails {
-				featureDetails = append(featureDetails, FeatureDetail{
-					Name:        feat["name"],
-					Description: feat["description"],
-					Vendor:      feat["vendor"],
-					Category:    category,
-				})
-			}
-			featureCategories[category] = featureDetails
+		supported[category] = cpuid.GetSupportedFeatures(category, false, "")
+	}
+
+	for _, feat := range dedupeFeatures(categories, supported) {
+		supportedFeatures = append(supportedFeatures, feat.name)
+
+		// Group supported features under their descriptive category name
+		detail, ok := detailsByName[feat.name]
+		if !ok {
+			detail = FeatureDetail{Name: feat.name, Category: feat.category}
 		}
+		featureCategories[detail.Category] = append(featureCategories[detail.Category], detail)
 	}
 
+	done()
+
 	// Get detailed cache info
+	done = profileStep("cache")
 	cacheInfo := []string{}
 	cacheDetails := []CacheDetail{}
-	caches, err := cpuid.GetCacheInfo(maxFunc, maxExtFunc, vendorID, false, "")
-	if err == nil {
+	caches, cacheErr := cpuid.GetCacheInfo(maxFunc, maxExtFunc, vendorID, false, "")
+	if cacheErr != nil {
+		partErrs = append(partErrs, fmt.Errorf("cache: %w", cacheErr))
+	} else {
 		for _, cache := range caches {
 			// Format cache information
 			cacheStr := fmt.Sprintf("L%d %s: %d KB, %d-way, %d bytes/line",
@@ -68,20 +88,24 @@ func collectCPUInfo() (*CPUInfo, error) {
 		}
 	}
 
+	done()
+
 	// Get TLB info
+	done = profileStep("TLB")
 	tlbInfo := TLBInfo{}
 	tlb, tlbErr := cpuid.GetTLBInfo(maxFunc, maxExtFunc, false, "")
-	if tlbErr == nil {
-		// Convert TLBLevel to TLBEntry slices - L1 has Data and Instruction, L2 has Unified
+	if tlbErr != nil {
+		partErrs = append(partErrs, fmt.Errorf("TLB: %w", tlbErr))
+	} else {
+		// Convert TLBLevel to TLBEntry slices - L1 has Data and Instruction, L2 and L3 have Unified
 		tlbInfo.L1Data = convertTLBEntries(tlb.L1.Data)
 		tlbInfo.L1Inst = convertTLBEntries(tlb.L1.Instruction)
 		tlbInfo.L2Unified = convertTLBEntries(tlb.L2.Unified)
-		// Also add L3 if available
-		if len(tlb.L3.Unified) > 0 {
-			tlbInfo.L2Unified = append(tlbInfo.L2Unified, convertTLBEntries(tlb.L3.Unified)...)
-		}
+		tlbInfo.L3Unified = convertTLBEntries(tlb.L3.Unified)
 	}
 
+	done()
+
 	// Get Hybrid info (Intel)
 	hybridInfo := HybridInfo{}
 	hybrid := cpuid.GetIntelHybrid(false, "")
@@ -103,17 +127,24 @@ func collectCPUInfo() (*CPUInfo, error) {
 	modelNum := modelData.ExtendedModel
 	stepping := modelData.SteppingID
 	cores := processorInfo.CoreCount
-	threads := processorInfo.ThreadPerCore * processorInfo.CoreCount
+	threads, threadsNote := reconcileThreads(processorInfo.CoreCount, processorInfo.ThreadPerCore, onlineCPUCount())
+	vendorName, brandString, restricted := cpuIdentity(vendorName, brandString)
+	goArch, pointerBits, byteOrder := collectArchInfo()
 
 	return &CPUInfo{
 		Vendor:            vendorName,
 		Brand:             brandString,
+		RestrictedCPUID:   restricted,
+		Hypervisor:        collectHypervisor(),
+		RawCPUID:          rawCPUID,
 		Model:             fmt.Sprintf("Family %d, Model %d, Stepping %d", family, modelNum, stepping),
 		Family:            family,
 		ModelNumber:       modelNum,
 		Stepping:          stepping,
+		Microcode:         collectMicrocode(),
 		Cores:             cores,
 		Threads:           threads,
+		ThreadsNote:       threadsNote,
 		Features:          supportedFeatures,
 		FeatureCategories: featureCategories,
 		CacheInfo:         cacheInfo,
@@ -142,7 +173,96 @@ func collectCPUInfo() (*CPUInfo, error) {
 		MaxExtFunc:       maxExtFunc,
 		PhysicalAddrBits: processorInfo.PhysicalAddressBits,
 		LinearAddrBits:   processorInfo.LinearAddressBits,
-	}, nil
+		BaseMHz:          collectBaseMHz(maxFunc),
+		GoArch:           goArch,
+		PointerBits:      pointerBits,
+		ByteOrder:        byteOrder,
+	}, partErrs, nil
+}
+
+// reconcileThreads checks CPUID's thread count, cores times threads per
+// core, against the logical CPUs the OS reports. The two disagree on hybrid
+// CPUs where only some cores have SMT, and on multi-socket machines, where
+// CPUID describes one package; the OS count is used then, with a note.
+func reconcileThreads(cores, threadsPerCore uint32, numCPU int) (uint32, string) {
+	threads := cores * threadsPerCore
+	if numCPU <= 0 || uint32(numCPU) == threads {
+		return threads, ""
+	}
+	return uint32(numCPU), fmt.Sprintf("CPUID reports %d cores x %d threads = %d; using the OS count of %d logical CPUs",
+		cores, threadsPerCore, threads, numCPU)
+}
+
+// onlineCPUCount returns the number of logical CPUs the OS has online.
+// runtime.NumCPU follows the process's affinity mask, which taskset and
+// cgroup cpusets narrow, so the sysfs online list is preferred where it
+// exists.
+func onlineCPUCount() int {
+	if cpus := parseCPUList(readSysfsString("/sys/devices/system/cpu/online")); len(cpus) > 0 {
+		return len(cpus)
+	}
+	return runtime.NumCPU()
+}
+
+// cpuIdentity cleans up the vendor name and brand string, which CPUID pads
+// with NULs and spaces, and substitutes placeholders for any that are empty,
+// as some hypervisors and emulators leave them. restricted reports whether
+// a placeholder was needed.
+func cpuIdentity(vendor, brand string) (string, string, bool) {
+	vendor = strings.Trim(vendor, " \x00")
+	brand = strings.Trim(brand, " \x00")
+	restricted := vendor == "" || brand == ""
+	if vendor == "" {
+		vendor = "Unknown vendor"
+	}
+	if brand == "" {
+		brand = "Unknown CPU"
+	}
+	return vendor, brand, restricted
+}
+
+// categorizedFeature is a supported feature and the cpuid category it was
+// first listed under.
+type categorizedFeature struct {
+	name     string
+	category string
+}
+
+// dedupeFeatures lists the supported features of each category, visited in
+// the order given. A feature can be listed under several categories, or
+// twice in one; only its first appearance is kept.
+func dedupeFeatures(categories []string, supported map[string][]string) []categorizedFeature {
+	features := []categorizedFeature{}
+	seen := make(map[string]struct{})
+	for _, category := range categories {
+		for _, name := range supported[category] {
+			if _, dup := seen[name]; dup {
+				continue
+			}
+			seen[name] = struct{}{}
+			features = append(features, categorizedFeature{name: name, category: category})
+		}
+	}
+	return features
+}
+
+// convertFeatureDetails turns cpuid's detailed feature lists into
+// FeatureDetails. The detailed map is keyed by display name rather than the
+// category keys that GetSupportedFeatures takes, so features are matched
+// by name instead.
+func convertFeatureDetails(detailed map[string][]map[string]string) map[string][]FeatureDetail {
+	result := make(map[string][]FeatureDetail, len(detailed))
+	for category, feats := range detailed {
+		for _, feat := range feats {
+			result[category] = append(result[category], FeatureDetail{
+				Name:        feat["name"],
+				Description: feat["description"],
+				Vendor:      feat["vendor"],
+				Category:    category,
+			})
+		}
+	}
+	return result
 }
 
 func convertTLBEntries(entries []cpuid.TLBEntry) []TLBEntry {
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDedupeFeatures(t *testing.T) {
+	categories := []string{"SIMD", "Crypto"}
+	supported := map[string][]string{
+		"SIMD":   {"AVX", "AESNI", "AVX"},
+		"Crypto": {"AESNI", "SHA"},
+	}
+
+	got := dedupeFeatures(categories, supported)
+	want := []categorizedFeature{
+		{name: "AVX", category: "SIMD"},
+		{name: "AESNI", category: "SIMD"},
+		{name: "SHA", category: "Crypto"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("dedupeFeatures() = %v, want %v", got, want)
+	}
+}
+
+func TestReconcileThreads(t *testing.T) {
+	tests := []struct {
+		name           string
+		cores          uint32
+		threadsPerCore uint32
+		numCPU         int
+		want           uint32
+		wantNote       bool
+	}{
+		{"equal", 4, 2, 8, 8, false},
+		{"hybrid partial SMT", 16, 2, 24, 24, true},
+		{"multi-socket", 8, 2, 32, 32, true},
+		{"unknown OS count", 4, 2, 0, 8, false},
+		{"negative OS count", 4, 2, -1, 8, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, note := reconcileThreads(tt.cores, tt.threadsPerCore, tt.numCPU)
+			if got != tt.want {
+				t.Errorf("threads = %d, want %d", got, tt.want)
+			}
+			if (note != "") != tt.wantNote {
+				t.Errorf("note = %q, want note: %v", note, tt.wantNote)
+			}
+		})
+	}
+}
+
+func TestCPUIdentity(t *testing.T) {
+	tests := []struct {
+		name           string
+		vendor, brand  string
+		wantVendor     string
+		wantBrand      string
+		wantRestricted bool
+	}{
+		{"both empty", "", "", "Unknown vendor", "Unknown CPU", true},
+		{"NUL padding only", "\x00\x00", "\x00\x00", "Unknown vendor", "Unknown CPU", true},
+		{"space padded", "  Intel  ", "  Intel(R) Core(TM) i7  ", "Intel", "Intel(R) Core(TM) i7", false},
+		{"empty vendor", "", "QEMU Virtual CPU", "Unknown vendor", "QEMU Virtual CPU", true},
+		{"empty brand", "GenuineIntel", "\x00", "GenuineIntel", "Unknown CPU", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vendor, brand, restricted := cpuIdentity(tt.vendor, tt.brand)
+			if vendor != tt.wantVendor || brand != tt.wantBrand || restricted != tt.wantRestricted {
+				t.Errorf("cpuIdentity(%q, %q) = %q, %q, %v; want %q, %q, %v",
+					tt.vendor, tt.brand, vendor, brand, restricted, tt.wantVendor, tt.wantBrand, tt.wantRestricted)
+			}
+		})
+	}
+}
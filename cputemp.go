@@ -0,0 +1,139 @@
+package main
+
+import (
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// cpuTempDrivers are the hwmon drivers that report CPU die temperatures.
+var cpuTempDrivers = map[string]bool{
+	"coretemp":    true, // Intel
+	"k10temp":     true, // AMD
+	"zenpower":    true, // AMD, out-of-tree
+	"cpu_thermal": true, // ARM SoCs
+}
+
+// collectCPUTemp reads the package and per-core temperatures in degrees
+// Celsius from the CPU's hwmon driver, falling back to the x86_pkg_temp
+// thermal zone for the package. A zero package temperature and nil core
+// temperatures mean no sensor was found.
+func collectCPUTemp() (packageC float64, cores []CoreTemp) {
+	for _, input := range cpuTempInputs() {
+		temp, ok := readMilliCelsius(input)
+		if !ok {
+			continue
+		}
+		label := sensorLabel(input)
+		switch {
+		case strings.HasPrefix(label, "Core"):
+			cores = append(cores, CoreTemp{Label: label, TempC: temp})
+		case packageC == 0 && (strings.HasPrefix(label, "Package") || label == "Tctl" || label == "Tdie" || label == ""):
+			packageC = temp
+		}
+	}
+
+	if packageC == 0 {
+		zones, _ := filepath.Glob("/sys/class/thermal/thermal_zone*")
+		for _, zone := range zones {
+			if readSysfsString(filepath.Join(zone, "type")) != "x86_pkg_temp" {
+				continue
+			}
+			if temp, ok := readMilliCelsius(filepath.Join(zone, "temp")); ok {
+				packageC = temp
+				break
+			}
+		}
+	}
+
+	return packageC, cores
+}
+
+// coreKey identifies a physical core. Core IDs restart in each package, so
+// on multi-socket machines the core ID alone is ambiguous.
+type coreKey struct {
+	pkg  int
+	core int
+}
+
+// collectCoreTempsByID reads the per-core temperatures keyed by package and
+// the core ID in their label ("Core 4"), which match the topology's
+// physical_package_id and core_id. Drivers without per-core sensors, such
+// as k10temp, leave it empty.
+func collectCoreTempsByID() map[coreKey]float64 {
+	temps := make(map[coreKey]float64)
+	packages := make(map[string]int) // Package ID of each hwmon directory
+	for _, input := range cpuTempInputs() {
+		id, err := strconv.Atoi(strings.TrimPrefix(sensorLabel(input), "Core "))
+		if err != nil {
+			continue
+		}
+		hwmon := filepath.Dir(input)
+		pkg, ok := packages[hwmon]
+		if !ok {
+			pkg = hwmonPackageID(hwmon)
+			packages[hwmon] = pkg
+		}
+		if temp, ok := readMilliCelsius(input); ok {
+			temps[coreKey{pkg: pkg, core: id}] = temp
+		}
+	}
+	return temps
+}
+
+// hwmonPackageID returns the package a coretemp hwmon device covers, from
+// its "Package id N" sensor label. coretemp registers one device per
+// package; other drivers have no such label and count as package 0.
+func hwmonPackageID(hwmon string) int {
+	labels, _ := filepath.Glob(filepath.Join(hwmon, "temp*_label"))
+	for _, label := range labels {
+		if id, ok := strings.CutPrefix(readSysfsString(label), "Package id "); ok {
+			if pkg, err := strconv.Atoi(id); err == nil {
+				return pkg
+			}
+		}
+	}
+	return 0
+}
+
+// cpuTempInputs returns the temperature inputs of every CPU hwmon driver,
+// each driver's sorted by sensor number.
+func cpuTempInputs() []string {
+	var inputs []string
+	hwmons, _ := filepath.Glob("/sys/class/hwmon/hwmon*")
+	for _, hwmon := range hwmons {
+		if !cpuTempDrivers[readSysfsString(filepath.Join(hwmon, "name"))] {
+			continue
+		}
+		found, _ := filepath.Glob(filepath.Join(hwmon, "temp*_input"))
+		sort.Slice(found, func(i, j int) bool {
+			return sensorIndex(found[i]) < sensorIndex(found[j])
+		})
+		inputs = append(inputs, found...)
+	}
+	return inputs
+}
+
+// sensorLabel reads the label next to a hwmon input, e.g. "Core 0" for
+// temp2_input.
+func sensorLabel(input string) string {
+	return readSysfsString(strings.TrimSuffix(input, "_input") + "_label")
+}
+
+// sensorIndex extracts N from a hwmon path such as tempN_input, fanN_input
+// or hwmonN so they sort numerically rather than temp10 before temp2.
+func sensorIndex(path string) int {
+	name := strings.TrimSuffix(strings.TrimLeft(filepath.Base(path), "abcdefghijklmnopqrstuvwxyz"), "_input")
+	n, _ := strconv.Atoi(name)
+	return n
+}
+
+// readMilliCelsius reads a sysfs temperature in millidegrees Celsius.
+func readMilliCelsius(path string) (float64, bool) {
+	milli, err := strconv.ParseInt(readSysfsString(path), 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return float64(milli) / 1000, true
+}
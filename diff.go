@@ -0,0 +1,305 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"sort"
+
+	"github.com/spf13/cobra"
+)
+
+var snapshotCmd = &cobra.Command{
+	Use:   "snapshot",
+	Short: "Save hardware information for a later diff",
+	Long:  "Collects hardware information and writes it as JSON, in the format the diff command reads. Use --output to write it to a file.",
+	Args:  cobra.NoArgs,
+	Run:   runJSON,
+}
+
+var diffCmd = &cobra.Command{
+	Use:   "diff <a.json> <b.json>",
+	Short: "Compare two hardware snapshots",
+	Long: "Loads two snapshots written by the snapshot command and prints the fields that differ, grouped by section. " +
+		"Readings that change from moment to moment (current clock speeds, temperatures, memory and disk usage) are ignored. " +
+		"Exits with status 1 when the snapshots differ and 2 when they can't be read.",
+	Args: cobra.ExactArgs(2),
+	Run:  runDiff,
+}
+
+func init() {
+	rootCmd.AddCommand(snapshotCmd)
+	rootCmd.AddCommand(diffCmd)
+}
+
+// volatileFields are skipped by the diff since they differ between any two
+// snapshots, even of the same machine. Keys are "Type.Field".
+var volatileFields = map[string]bool{
+	"CPUInfo.CurrentMHz":                true,
+	"CPUInfo.PackageTempC":              true,
+	"CPUInfo.CoreTemps":                 true,
+	"ProcessorInfoDetail.InitialAPICID": true, // APIC ID of whichever core ran CPUID
+	"HybridInfo.CoreType":               true, // Likewise the type of that core
+	"CPUInfo.RawCPUID":                  true, // Includes that APIC ID, and varies by core
+	"RAMInfo.AvailableBytes":            true,
+	"RAMInfo.UsedBytes":                 true,
+	"RAMInfo.FreeBytes":                 true,
+	"RAMInfo.BuffersBytes":              true,
+	"RAMInfo.CachedBytes":               true,
+	"RAMInfo.SwapFreeBytes":             true,
+	"DiskDevice.FreeBytes":              true,
+	"DiskDevice.UsedBytes":              true,
+	"SystemStats.UptimeSeconds":         true,
+	"SystemStats.Load1":                 true,
+	"SystemStats.Load5":                 true,
+	"SystemStats.Load15":                true,
+	"BatteryDevice.ChargePercent":       true,
+	"BatteryDevice.State":               true,
+	"Sensor.Value":                      true,
+}
+
+// elementKeyFields name the struct fields that identify a slice element, so
+// e.g. disks are matched by mount point rather than by position.
+var elementKeyFields = []string{"Name", "MountPoint", "Card", "Address"}
+
+func runDiff(cmd *cobra.Command, args []string) {
+	a, err := loadSnapshot(args[0])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading snapshot: %v\n", err)
+		os.Exit(2)
+	}
+	b, err := loadSnapshot(args[1])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading snapshot: %v\n", err)
+		os.Exit(2)
+	}
+
+	if writeSnapshotDiff(os.Stdout, a, b) {
+		os.Exit(1)
+	}
+}
+
+func loadSnapshot(path string) (*HardwareInfo, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var info HardwareInfo
+	if err := json.Unmarshal(data, &info); err != nil {
+		var syntaxErr *json.SyntaxError
+		if errors.As(err, &syntaxErr) {
+			line, col := textPosition(data, syntaxErr.Offset)
+			return nil, fmt.Errorf("%s:%d:%d: malformed JSON: %w", path, line, col, err)
+		}
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	// Valid JSON of some other shape decodes to an empty HardwareInfo
+	if info.CPU.Vendor == "" && info.CPU.Brand == "" && len(info.CPU.Features) == 0 {
+		return nil, fmt.Errorf("%s: no CPU information found; is it a snapshot written by the snapshot or json command?", path)
+	}
+	return &info, nil
+}
+
+// textPosition converts a byte offset into data to a 1-based line and
+// column for error messages.
+func textPosition(data []byte, offset int64) (line, col int) {
+	before := data[:min(int(offset), len(data))]
+	line = bytes.Count(before, []byte("\n")) + 1
+	col = len(before) - bytes.LastIndexByte(before, '\n')
+	return line, col
+}
+
+// writeSnapshotDiff prints the differences between a and b, one section
+// (CPU, RAM, ...) at a time, and reports whether there were any.
+func writeSnapshotDiff(w io.Writer, a, b *HardwareInfo) bool {
+	va, vb := reflect.ValueOf(*a), reflect.ValueOf(*b)
+	differs := false
+	for i := 0; i < va.NumField(); i++ {
+		var changes []string
+		diffValues("", va.Field(i), vb.Field(i), &changes)
+		if len(changes) == 0 {
+			continue
+		}
+		differs = true
+		fmt.Fprintf(w, "%s:\n", va.Type().Field(i).Name)
+		for _, change := range changes {
+			fmt.Fprintf(w, "  %s\n", change)
+		}
+	}
+	return differs
+}
+
+// diffValues appends a line to changes for every leaf that differs between
+// a and b, which have the same type.
+func diffValues(path string, a, b reflect.Value, changes *[]string) {
+	switch a.Kind() {
+	case reflect.Struct:
+		for i := 0; i < a.NumField(); i++ {
+			field := a.Type().Field(i)
+			if volatileFields[a.Type().Name()+"."+field.Name] {
+				continue
+			}
+			diffValues(joinPath(path, field.Name), a.Field(i), b.Field(i), changes)
+		}
+
+	case reflect.Map:
+		keys := make(map[string]bool)
+		for _, k := range a.MapKeys() {
+			keys[k.String()] = true
+		}
+		for _, k := range b.MapKeys() {
+			keys[k.String()] = true
+		}
+		for _, key := range sortedKeys(keys) {
+			k := reflect.ValueOf(key)
+			av, bv := a.MapIndex(k), b.MapIndex(k)
+			keyPath := fmt.Sprintf("%s[%s]", path, key)
+			switch {
+			case !av.IsValid():
+				*changes = append(*changes, fmt.Sprintf("%s: added", keyPath))
+			case !bv.IsValid():
+				*changes = append(*changes, fmt.Sprintf("%s: removed", keyPath))
+			default:
+				diffValues(keyPath, av, bv, changes)
+			}
+		}
+
+	case reflect.Slice:
+		if a.Type().Elem().Kind() == reflect.String {
+			diffStringSets(path, a, b, changes)
+			return
+		}
+		if keyField(a.Type().Elem()) != "" {
+			diffKeyedSlices(path, a, b, changes)
+			return
+		}
+		for i := 0; i < max(a.Len(), b.Len()); i++ {
+			elemPath := fmt.Sprintf("%s[%d]", path, i)
+			switch {
+			case i >= a.Len():
+				*changes = append(*changes, fmt.Sprintf("%s: added %s", elemPath, formatDiffValue(b.Index(i))))
+			case i >= b.Len():
+				*changes = append(*changes, fmt.Sprintf("%s: removed %s", elemPath, formatDiffValue(a.Index(i))))
+			default:
+				diffValues(elemPath, a.Index(i), b.Index(i), changes)
+			}
+		}
+
+	default:
+		if !reflect.DeepEqual(a.Interface(), b.Interface()) {
+			*changes = append(*changes, fmt.Sprintf("%s: %s -> %s", path, formatDiffValue(a), formatDiffValue(b)))
+		}
+	}
+}
+
+// diffStringSets reports strings present in only one of a and b, ignoring
+// order, as with the feature list.
+func diffStringSets(path string, a, b reflect.Value, changes *[]string) {
+	inA, inB := make(map[string]bool), make(map[string]bool)
+	for i := 0; i < a.Len(); i++ {
+		inA[a.Index(i).String()] = true
+	}
+	for i := 0; i < b.Len(); i++ {
+		inB[b.Index(i).String()] = true
+	}
+	for _, s := range sortedKeys(inA) {
+		if !inB[s] {
+			*changes = append(*changes, fmt.Sprintf("%s: -%s", path, s))
+		}
+	}
+	for _, s := range sortedKeys(inB) {
+		if !inA[s] {
+			*changes = append(*changes, fmt.Sprintf("%s: +%s", path, s))
+		}
+	}
+}
+
+// diffKeyedSlices matches slice elements by their elementKey instead of by
+// position, so one inserted element doesn't shift every later comparison.
+func diffKeyedSlices(path string, a, b reflect.Value, changes *[]string) {
+	indexA, indexB := indexByKey(a), indexByKey(b)
+	keys := make(map[string]bool)
+	for key := range indexA {
+		keys[key] = true
+	}
+	for key := range indexB {
+		keys[key] = true
+	}
+	for _, key := range sortedKeys(keys) {
+		elemPath := fmt.Sprintf("%s[%s]", path, key)
+		ia, okA := indexA[key]
+		ib, okB := indexB[key]
+		switch {
+		case !okA:
+			*changes = append(*changes, fmt.Sprintf("%s: added", elemPath))
+		case !okB:
+			*changes = append(*changes, fmt.Sprintf("%s: removed", elemPath))
+		default:
+			diffValues(elemPath, a.Index(ia), b.Index(ib), changes)
+		}
+	}
+}
+
+// indexByKey maps each element's key to its index in v. Keys can repeat,
+// such as the coretemp chips of a two-socket machine, so the second and later
+// occurrences get "#2", "#3", ... appended and are matched in order rather
+// than overwriting the first.
+func indexByKey(v reflect.Value) map[string]int {
+	index := make(map[string]int, v.Len())
+	seen := make(map[string]int)
+	for i := 0; i < v.Len(); i++ {
+		key := elementKey(v.Index(i))
+		seen[key]++
+		if n := seen[key]; n > 1 {
+			key = fmt.Sprintf("%s#%d", key, n)
+		}
+		index[key] = i
+	}
+	return index
+}
+
+// keyField returns the first of elementKeyFields that t has as a string
+// field, or "" if t isn't a struct or has none of them.
+func keyField(t reflect.Type) string {
+	if t.Kind() != reflect.Struct {
+		return ""
+	}
+	for _, name := range elementKeyFields {
+		if field, ok := t.FieldByName(name); ok && field.Type.Kind() == reflect.String {
+			return name
+		}
+	}
+	return ""
+}
+
+// elementKey returns the value of v's key field.
+func elementKey(v reflect.Value) string {
+	return v.FieldByName(keyField(v.Type())).String()
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+func formatDiffValue(v reflect.Value) string {
+	if v.Kind() == reflect.String {
+		return fmt.Sprintf("%q", v.String())
+	}
+	return fmt.Sprintf("%+v", v.Interface())
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
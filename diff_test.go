@@ -0,0 +1,28 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffKeyedSlicesDuplicateKeys(t *testing.T) {
+	a := []SensorChip{
+		{Name: "coretemp", Device: "hwmon1"},
+		{Name: "coretemp", Device: "hwmon2"},
+	}
+	b := []SensorChip{
+		{Name: "coretemp", Device: "hwmon1"},
+		{Name: "coretemp", Device: "hwmon3"},
+		{Name: "coretemp", Device: "hwmon4"},
+	}
+
+	var changes []string
+	diffKeyedSlices("Chips", reflect.ValueOf(a), reflect.ValueOf(b), &changes)
+	want := []string{
+		`Chips[coretemp#2].Device: "hwmon2" -> "hwmon3"`,
+		"Chips[coretemp#3]: added",
+	}
+	if !reflect.DeepEqual(changes, want) {
+		t.Errorf("changes = %q, want %q", changes, want)
+	}
+}
@@ -0,0 +1,180 @@
+//go:build linux
+
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// pseudoFilesystems are kernel interfaces that show up in /proc/mounts but
+// don't represent storage.
+var pseudoFilesystems = map[string]bool{
+	"proc":        true,
+	"sysfs":       true,
+	"devpts":      true,
+	"cgroup":      true,
+	"cgroup2":     true,
+	"securityfs":  true,
+	"debugfs":     true,
+	"tracefs":     true,
+	"configfs":    true,
+	"pstore":      true,
+	"bpf":         true,
+	"mqueue":      true,
+	"hugetlbfs":   true,
+	"fusectl":     true,
+	"binfmt_misc": true,
+	"autofs":      true,
+	"efivarfs":    true,
+	"nsfs":        true,
+	"rpc_pipefs":  true,
+}
+
+func collectDiskInfo() (*DiskInfo, error) {
+	file, err := os.Open("/proc/mounts")
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	info := &DiskInfo{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 3 {
+			continue
+		}
+		device := unescapeMountField(fields[0])
+		mountPoint := unescapeMountField(fields[1])
+		fsType := fields[2]
+		if pseudoFilesystems[fsType] {
+			continue
+		}
+
+		disk := DiskDevice{
+			Device:     device,
+			MountPoint: mountPoint,
+			FSType:     fsType,
+		}
+
+		var stat syscall.Statfs_t
+		if err := syscall.Statfs(mountPoint, &stat); err == nil {
+			blockSize := uint64(stat.Bsize)
+			disk.TotalBytes = stat.Blocks * blockSize
+			disk.FreeBytes = stat.Bavail * blockSize
+			if used := (stat.Blocks - stat.Bfree) * blockSize; used <= disk.TotalBytes {
+				disk.UsedBytes = used
+			}
+		}
+
+		info.Devices = append(info.Devices, disk)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	collectDiskHealth(info.Devices)
+
+	return info, nil
+}
+
+// unescapeMountField decodes the octal escapes (e.g. \040 for a space)
+// that /proc/mounts uses for whitespace in paths.
+func unescapeMountField(s string) string {
+	if !strings.Contains(s, "\\") {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+3 < len(s) {
+			if n, err := strconv.ParseUint(s[i+1:i+4], 8, 8); err == nil {
+				b.WriteByte(byte(n))
+				i += 3
+				continue
+			}
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+// collectDiskHealth fills in the SMART overall-health of each device's
+// underlying disk. Without smartctl, or for devices that aren't block
+// devices (tmpfs, overlay, network mounts), the health is left unknown.
+func collectDiskHealth(devices []DiskDevice) {
+	smartctl, _ := exec.LookPath("smartctl")
+
+	// Several partitions usually share one disk, so query each disk once
+	results := make(map[string]string)
+	for i := range devices {
+		disk := parentBlockDevice(devices[i].Device)
+		if disk == "" || smartctl == "" {
+			devices[i].Health = diskHealthUnknown
+			continue
+		}
+		health, ok := results[disk]
+		if !ok {
+			health = querySMARTHealth(smartctl, disk)
+			results[disk] = health
+		}
+		devices[i].Health = health
+	}
+}
+
+// parentBlockDevice returns the /dev path of the whole disk that holds
+// device, or "" if device isn't a block device.
+func parentBlockDevice(device string) string {
+	if !strings.HasPrefix(device, "/dev/") {
+		return ""
+	}
+	resolved, err := filepath.EvalSymlinks(device)
+	if err != nil {
+		return ""
+	}
+	name := filepath.Base(resolved)
+	sysPath, err := filepath.EvalSymlinks(filepath.Join("/sys/class/block", name))
+	if err != nil {
+		return ""
+	}
+	// A partition's sysfs directory sits inside its disk's directory
+	if _, err := os.Stat(filepath.Join(sysPath, "partition")); err == nil {
+		name = filepath.Base(filepath.Dir(sysPath))
+	}
+	return "/dev/" + name
+}
+
+// smartctlTimeout bounds each smartctl run, so a disk or controller that
+// hangs the query can't stall collection; the health is then unknown.
+const smartctlTimeout = 5 * time.Second
+
+// querySMARTHealth runs smartctl -H against disk and maps its verdict to one
+// of the diskHealth values.
+func querySMARTHealth(smartctl, disk string) string {
+	ctx, cancel := context.WithTimeout(context.Background(), smartctlTimeout)
+	defer cancel()
+
+	// smartctl encodes problems in its exit status bits but still prints
+	// the report, so the output is parsed even when err is set
+	out, _ := exec.CommandContext(ctx, smartctl, "-H", "-j", disk).Output()
+	var report struct {
+		SMARTStatus *struct {
+			Passed bool `json:"passed"`
+		} `json:"smart_status"`
+	}
+	if err := json.Unmarshal(out, &report); err != nil || report.SMARTStatus == nil {
+		return diskHealthUnknown
+	}
+	if report.SMARTStatus.Passed {
+		return diskHealthPassed
+	}
+	return diskHealthFailed
+}
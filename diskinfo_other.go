@@ -0,0 +1,9 @@
+//go:build !linux
+
+package main
+
+import "fmt"
+
+func collectDiskInfo() (*DiskInfo, error) {
+	return nil, fmt.Errorf("disk information is not supported on this platform")
+}
@@ -0,0 +1,183 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var jsonCmd = &cobra.Command{
+	Use:   "json",
+	Short: "Print hardware information as JSON",
+	Long:  "Collects hardware information and prints it to stdout as indented JSON without starting the TUI.",
+	Args:  cobra.NoArgs,
+	Run:   runJSON,
+}
+
+var yamlCmd = &cobra.Command{
+	Use:   "yaml",
+	Short: "Print hardware information as YAML",
+	Long:  "Collects hardware information and prints it to stdout as YAML without starting the TUI.",
+	Args:  cobra.NoArgs,
+	Run:   runYAML,
+}
+
+var dumpCmd = &cobra.Command{
+	Use:   "dump",
+	Short: "Print CPU information as plain text",
+	Long:  "Collects hardware information and prints the CPU page's sections as plain text, suitable for piping or grepping.",
+	Args:  cobra.NoArgs,
+	Run:   runDump,
+}
+
+var (
+	outputPath         string
+	exportDescriptions bool
+	exportFields       string
+)
+
+func init() {
+	for _, cmd := range []*cobra.Command{jsonCmd, yamlCmd, dumpCmd, snapshotCmd, prometheusCmd, markdownCmd, htmlCmd, categoriesCmd} {
+		cmd.Flags().StringVarP(&outputPath, "output", "o", "", "Write to this file instead of stdout")
+	}
+	for _, cmd := range []*cobra.Command{jsonCmd, yamlCmd, dumpCmd} {
+		cmd.Flags().BoolVar(&exportDescriptions, "descriptions", false, "Include each feature's vendor and description")
+	}
+	for _, cmd := range []*cobra.Command{jsonCmd, yamlCmd} {
+		cmd.Flags().StringVar(&exportFields, "fields", "", "Only output these comma-separated dotted fields, e.g. cpu.cores,cpu.features")
+	}
+	rootCmd.AddCommand(jsonCmd)
+	rootCmd.AddCommand(yamlCmd)
+	rootCmd.AddCommand(dumpCmd)
+}
+
+// collectForExport collects hardware info for the headless export commands,
+// exiting with a non-zero status if collection fails.
+func collectForExport() *HardwareInfo {
+	hwInfo, err := CollectHardwareInfo()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error collecting hardware info: %v\n", err)
+		os.Exit(1)
+	}
+	writeCollectionErrors(os.Stderr, hwInfo.CollectionErrors)
+	if exportDescriptions {
+		attachFeatureDetails(&hwInfo.CPU)
+	}
+	return hwInfo
+}
+
+// exportValue returns what the json and yaml commands encode: hwInfo, or
+// just the --fields selection keyed by keyName. A bad path exits with an
+// error naming the fields available there.
+func exportValue(hwInfo *HardwareInfo, keyName func(reflect.StructField) string) any {
+	if exportFields == "" {
+		return hwInfo
+	}
+	selected, err := selectFields(hwInfo, exportFields, keyName)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error in --fields: %v\n", err)
+		os.Exit(1)
+	}
+	return selected
+}
+
+// attachFeatureDetails fills in cpu.FeatureDetails in the order of
+// cpu.Features. Features without details keep just their name.
+func attachFeatureDetails(cpu *CPUInfo) {
+	details := featureDetailIndex(cpu.FeatureCategories)
+	cpu.FeatureDetails = make([]FeatureDetail, 0, len(cpu.Features))
+	for _, name := range cpu.Features {
+		detail, ok := details[name]
+		if !ok {
+			detail = FeatureDetail{Name: name}
+		}
+		cpu.FeatureDetails = append(cpu.FeatureDetails, detail)
+	}
+}
+
+// writeOutput calls write with stdout, or with a temp file next to path
+// that is renamed over path once write succeeds, so a failed export never
+// leaves a half-written file behind.
+func writeOutput(path string, write func(w io.Writer) error) error {
+	if path == "" {
+		return write(os.Stdout)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) // no-op once renamed
+
+	if err := write(tmp); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(0o644); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func runJSON(cmd *cobra.Command, args []string) {
+	out := exportValue(collectForExport(), jsonFieldName)
+
+	err := writeOutput(outputPath, func(w io.Writer) error {
+		return writeJSON(w, out)
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error encoding JSON: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func runYAML(cmd *cobra.Command, args []string) {
+	out := exportValue(collectForExport(), yamlFieldName)
+
+	err := writeOutput(outputPath, func(w io.Writer) error {
+		return writeYAML(w, out)
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error encoding YAML: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// writeJSON encodes v as indented JSON.
+func writeJSON(w io.Writer, v any) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+// writeYAML encodes v as YAML indented by two spaces.
+func writeYAML(w io.Writer, v any) error {
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(v); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
+
+func runDump(cmd *cobra.Command, args []string) {
+	hwInfo := collectForExport()
+
+	err := writeOutput(outputPath, func(w io.Writer) error {
+		return writeTextReport(w, hwInfo)
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing report: %v\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+)
+
+var exportDir string
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Write the hardware report in every format to a directory",
+	Long: "Collects hardware information once and writes it to hardware.json, hardware.yaml, hardware.txt and hardware.md in the " +
+		"directory given with --dir, creating it if needed. Each written path is printed; a format that fails doesn't stop the others, " +
+		"but the exit status is non-zero.",
+	Args: cobra.NoArgs,
+	Run:  runExport,
+}
+
+func init() {
+	exportCmd.Flags().StringVar(&exportDir, "dir", "", "Directory to write the files to")
+	exportCmd.MarkFlagRequired("dir")
+	rootCmd.AddCommand(exportCmd)
+}
+
+// exportFormat is one file written by the export command.
+type exportFormat struct {
+	file  string
+	write func(w io.Writer, hwInfo *HardwareInfo) error
+}
+
+var exportFormats = []exportFormat{
+	{"hardware.json", func(w io.Writer, hwInfo *HardwareInfo) error { return writeJSON(w, hwInfo) }},
+	{"hardware.yaml", func(w io.Writer, hwInfo *HardwareInfo) error { return writeYAML(w, hwInfo) }},
+	{"hardware.txt", writeTextReport},
+	{"hardware.md", writeMarkdownReport},
+}
+
+func runExport(cmd *cobra.Command, args []string) {
+	if err := os.MkdirAll(exportDir, 0o755); err != nil {
+		fmt.Fprintf(os.Stderr, "Error creating %s: %v\n", exportDir, err)
+		os.Exit(1)
+	}
+	hwInfo := collectForExport()
+
+	failed := false
+	for _, format := range exportFormats {
+		path := filepath.Join(exportDir, format.file)
+		err := writeOutput(path, func(w io.Writer) error {
+			return format.write(w, hwInfo)
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing %s: %v\n", path, err)
+			failed = true
+			continue
+		}
+		fmt.Println(path)
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	featuresCSV        bool
+	featuresCategory   string
+	featuresCount      bool
+	featuresByCategory bool
+)
+
+var featuresCmd = &cobra.Command{
+	Use:   "features",
+	Short: "Print supported CPU features",
+	Long: "Prints the supported CPU features one per line, or every feature with its category, vendor and description as CSV, " +
+		"or only how many there are.",
+	Args: cobra.NoArgs,
+	Run:  runFeatures,
+}
+
+func init() {
+	featuresCmd.Flags().BoolVar(&featuresCSV, "csv", false, "Print Name, Category, Vendor and Description as CSV")
+	featuresCmd.Flags().StringVar(&featuresCategory, "category", "", "Only print features in this category (case-insensitive)")
+	featuresCmd.Flags().BoolVar(&featuresCount, "count", false, "Only print the number of supported features")
+	featuresCmd.Flags().BoolVar(&featuresByCategory, "by-category", false, "With --count, print \"category: N\" for each category")
+	featuresCmd.MarkFlagsMutuallyExclusive("count", "csv")
+	rootCmd.AddCommand(featuresCmd)
+}
+
+func runFeatures(cmd *cobra.Command, args []string) {
+	if featuresByCategory && !featuresCount {
+		fmt.Fprintln(os.Stderr, "Error: --by-category only applies with --count")
+		os.Exit(1)
+	}
+
+	// A count only needs CPUID, so skip the other collectors
+	var cpu *CPUInfo
+	if featuresCount {
+		var partErrs []error
+		var err error
+		if cpu, partErrs, err = collectCPUInfo(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error collecting CPU info: %v\n", err)
+			os.Exit(1)
+		}
+		writeCollectionErrors(os.Stderr, partErrs)
+	} else {
+		cpu = &collectForExport().CPU
+	}
+	features := cpu.Features
+	categories := cpu.FeatureCategories
+
+	if featuresCategory != "" {
+		name, err := findCategory(categories, featuresCategory)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		categories = map[string][]FeatureDetail{name: categories[name]}
+		features = make([]string, 0, len(categories[name]))
+		for _, feat := range categories[name] {
+			features = append(features, feat.Name)
+		}
+	}
+
+	if featuresCount {
+		if !featuresByCategory {
+			fmt.Println(len(features))
+			return
+		}
+		for _, name := range sortedCategoryNames(categories) {
+			fmt.Printf("%s: %d\n", name, len(categories[name]))
+		}
+		return
+	}
+
+	if !featuresCSV {
+		for _, feature := range features {
+			fmt.Println(feature)
+		}
+		return
+	}
+
+	if err := writeFeaturesCSV(os.Stdout, categories); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing CSV: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// findCategory returns the key of categories that matches name ignoring
+// case, or an error listing the available categories.
+func findCategory(categories map[string][]FeatureDetail, name string) (string, error) {
+	names := sortedCategoryNames(categories)
+	for _, category := range names {
+		if strings.EqualFold(category, name) {
+			return category, nil
+		}
+	}
+	return "", fmt.Errorf("unknown category %q (available categories: %s)", name, strings.Join(names, ", "))
+}
+
+// writeFeaturesCSV writes one row per feature, in sorted category order so
+// the output is stable between runs.
+func writeFeaturesCSV(w io.Writer, categories map[string][]FeatureDetail) error {
+	names := sortedCategoryNames(categories)
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write([]string{"Name", "Category", "Vendor", "Description"}); err != nil {
+		return err
+	}
+	for _, name := range names {
+		for _, feat := range categories[name] {
+			if err := writer.Write([]string{feat.Name, feat.Category, feat.Vendor, feat.Description}); err != nil {
+				return err
+			}
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
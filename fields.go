@@ -0,0 +1,136 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// selectFields builds a nested map holding only the fields named by paths,
+// a comma-separated list of dotted paths such as "cpu.cores,ram". Path
+// segments match a field's YAML or Go name ignoring case, or a map key
+// exactly. keyName gives the key each field gets in the result, so the
+// filtered output uses the same keys as the unfiltered one.
+func selectFields(v any, paths string, keyName func(reflect.StructField) string) (map[string]any, error) {
+	result := make(map[string]any)
+	for _, path := range strings.Split(paths, ",") {
+		path = strings.TrimSpace(path)
+		if path == "" {
+			continue
+		}
+
+		value := reflect.ValueOf(v)
+		node := result
+		segments := strings.Split(path, ".")
+	walk:
+		for i, segment := range segments {
+			for value.Kind() == reflect.Pointer {
+				value = value.Elem()
+			}
+
+			var key string
+			switch value.Kind() {
+			case reflect.Struct:
+				field, ok := findField(value.Type(), segment)
+				if !ok {
+					return nil, fmt.Errorf("unknown field %q in %q (available: %s)",
+						segment, path, strings.Join(fieldNames(value.Type()), ", "))
+				}
+				key = keyName(field)
+				value = value.FieldByIndex(field.Index)
+			case reflect.Map:
+				entry := value.MapIndex(reflect.ValueOf(segment))
+				if !entry.IsValid() {
+					return nil, fmt.Errorf("unknown key %q in %q (available: %s)",
+						segment, path, strings.Join(mapKeys(value), ", "))
+				}
+				key = segment
+				value = entry
+			default:
+				return nil, fmt.Errorf("%q in %q has no fields to select (it is a %s)",
+					strings.Join(segments[:i], "."), path, value.Kind())
+			}
+
+			if i == len(segments)-1 {
+				node[key] = value.Interface()
+				break
+			}
+			existing, selected := node[key]
+			child, ok := existing.(map[string]any)
+			switch {
+			case selected && !ok:
+				break walk // An earlier path selected all of it
+			case !selected:
+				child = make(map[string]any)
+				node[key] = child
+			}
+			node = child
+		}
+	}
+
+	if len(result) == 0 {
+		return nil, fmt.Errorf("no fields given")
+	}
+	return result, nil
+}
+
+// findField returns the exported field of t whose YAML or Go name is name,
+// ignoring case.
+func findField(t reflect.Type, name string) (reflect.StructField, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() || yamlFieldName(field) == "" {
+			continue
+		}
+		if strings.EqualFold(yamlFieldName(field), name) || strings.EqualFold(field.Name, name) {
+			return field, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// fieldNames lists the YAML names of t's selectable fields for error hints.
+func fieldNames(t reflect.Type) []string {
+	var names []string
+	for i := 0; i < t.NumField(); i++ {
+		if name := yamlFieldName(t.Field(i)); name != "" && t.Field(i).IsExported() {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// mapKeys lists a string-keyed map's keys, sorted, for error hints.
+func mapKeys(m reflect.Value) []string {
+	keys := make(map[string]bool, m.Len())
+	for _, k := range m.MapKeys() {
+		keys[k.String()] = true
+	}
+	return sortedKeys(keys)
+}
+
+// yamlFieldName is the key yaml.v3 encodes field under, or "" if the field
+// is skipped.
+func yamlFieldName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+	switch name {
+	case "-":
+		return ""
+	case "":
+		return strings.ToLower(field.Name)
+	}
+	return name
+}
+
+// jsonFieldName is the key encoding/json encodes field under, or "" if the
+// field is skipped.
+func jsonFieldName(field reflect.StructField) string {
+	name, opts, _ := strings.Cut(field.Tag.Get("json"), ",")
+	switch {
+	case name == "-" && opts == "":
+		return ""
+	case name == "":
+		return field.Name
+	}
+	return name
+}
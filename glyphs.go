@@ -0,0 +1,77 @@
+package main
+
+// glyphSet holds the box-drawing and symbol characters the TUI draws with,
+// so --ascii can swap them all for plain ASCII.
+type glyphSet struct {
+	topLeft, topRight       rune
+	bottomLeft, bottomRight rune
+	horizontal, vertical    rune
+	titleHorizontal         rune // Top border, either side of the page title
+	sectionRule             string
+	bullet                  string // Collapsed category marker on the CPU page
+	expanded                string // Expanded category marker
+	scrollThumb             rune
+	dotOn, dotOff           string // Page position indicator
+	treeBranch, treeLast    string
+	treeStem                string
+	leftRight, upDown       string // Arrow keys in the instruction line
+	degree                  string
+	barFull, barEmpty       string // Charge and size bars
+}
+
+var unicodeGlyphs = glyphSet{
+	topLeft:         '┌',
+	topRight:        '┐',
+	bottomLeft:      '└',
+	bottomRight:     '┘',
+	horizontal:      '─',
+	vertical:        '│',
+	titleHorizontal: '═',
+	sectionRule:     "───",
+	bullet:          "▸",
+	expanded:        "▾",
+	scrollThumb:     '█',
+	dotOn:           "●",
+	dotOff:          "○",
+	treeBranch:      "├─",
+	treeLast:        "└─",
+	treeStem:        "│ ",
+	leftRight:       "← →",
+	upDown:          "↑ ↓",
+	degree:          "°",
+	barFull:         "█",
+	barEmpty:        "░",
+}
+
+var asciiGlyphs = glyphSet{
+	topLeft:         '+',
+	topRight:        '+',
+	bottomLeft:      '+',
+	bottomRight:     '+',
+	horizontal:      '-',
+	vertical:        '|',
+	titleHorizontal: '=',
+	sectionRule:     "---",
+	bullet:          ">",
+	expanded:        "v",
+	scrollThumb:     '#',
+	dotOn:           "*",
+	dotOff:          ".",
+	treeBranch:      "|-",
+	treeLast:        "`-",
+	treeStem:        "| ",
+	leftRight:       "<- ->",
+	upDown:          "^ v",
+	degree:          "",
+	barFull:         "#",
+	barEmpty:        ".",
+}
+
+// selectedGlyphs returns the glyph set --ascii picks, for the TUI and the
+// text, Markdown and HTML reports alike.
+func selectedGlyphs() *glyphSet {
+	if asciiOnly {
+		return &asciiGlyphs
+	}
+	return &unicodeGlyphs
+}
@@ -5,9 +5,14 @@ go 1.24.2
 replace retrotui => github.com/earentir/retrotui v0.0.0-20250418172315-2622ef534fd7
 
 require (
+	github.com/atotto/clipboard v0.1.4
 	github.com/earentir/cpuid v1.0.8
 	github.com/gdamore/tcell/v2 v2.8.1
+	github.com/mattn/go-runewidth v0.0.16
 	github.com/spf13/cobra v1.10.1
+	golang.org/x/sys v0.29.0
+	golang.org/x/term v0.28.0
+	gopkg.in/yaml.v3 v3.0.1
 	retrotui v0.0.0-20250418172315-2622ef534fd7
 )
 
@@ -15,10 +20,7 @@ require (
 	github.com/gdamore/encoding v1.0.1 // indirect
 	github.com/inconshreveable/mousetrap v1.1.0 // indirect
 	github.com/lucasb-eyer/go-colorful v1.2.0 // indirect
-	github.com/mattn/go-runewidth v0.0.16 // indirect
 	github.com/rivo/uniseg v0.4.3 // indirect
 	github.com/spf13/pflag v1.0.9 // indirect
-	golang.org/x/sys v0.29.0 // indirect
-	golang.org/x/term v0.28.0 // indirect
 	golang.org/x/text v0.21.0 // indirect
 )
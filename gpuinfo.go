@@ -0,0 +1,89 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// pciVendorNames covers the vendors that ship display controllers.
+var pciVendorNames = map[uint16]string{
+	0x1002: "AMD",
+	0x1022: "AMD",
+	0x10de: "NVIDIA",
+	0x8086: "Intel",
+	0x1a03: "ASPEED",
+	0x102b: "Matrox",
+	0x15ad: "VMware",
+	0x1234: "QEMU",
+	0x1af4: "Red Hat (virtio)",
+	0x80ee: "VirtualBox",
+	0x1414: "Microsoft",
+	0x5143: "Qualcomm",
+	0x13b5: "ARM",
+}
+
+func collectGPUInfo() (*GPUInfo, error) {
+	// Each DRM card links to its PCI device; connectors (card0-HDMI-A-1)
+	// share the prefix but are skipped
+	cards, err := filepath.Glob("/sys/class/drm/card[0-9]*")
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(cards)
+
+	info := &GPUInfo{}
+	for _, card := range cards {
+		name := filepath.Base(card)
+		if strings.Contains(name, "-") {
+			continue
+		}
+		deviceDir := filepath.Join(card, "device")
+
+		gpu := GPUDevice{
+			Card:     name,
+			VendorID: readSysfsHex(filepath.Join(deviceDir, "vendor")),
+			DeviceID: readSysfsHex(filepath.Join(deviceDir, "device")),
+		}
+		gpu.Vendor = pciVendorNames[gpu.VendorID]
+		if gpu.Vendor == "" {
+			gpu.Vendor = "Unknown vendor"
+		}
+		gpu.Model = readSysfsString(filepath.Join(deviceDir, "product_name"))
+		if link, err := os.Readlink(deviceDir); err == nil {
+			gpu.PCIAddress = filepath.Base(link)
+		}
+		if link, err := os.Readlink(filepath.Join(deviceDir, "driver")); err == nil {
+			gpu.Driver = filepath.Base(link)
+		}
+		// amdgpu exposes VRAM directly; other drivers don't
+		if vram := readSysfsString(filepath.Join(deviceDir, "mem_info_vram_total")); vram != "" {
+			gpu.VRAMBytes, _ = strconv.ParseUint(vram, 10, 64)
+		}
+
+		info.Devices = append(info.Devices, gpu)
+	}
+
+	return info, nil
+}
+
+// readSysfsString returns the trimmed contents of a sysfs attribute, or ""
+// if it can't be read.
+func readSysfsString(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
+// readSysfsHex parses a sysfs attribute holding a 0x-prefixed ID.
+func readSysfsHex(path string) uint16 {
+	value, err := strconv.ParseUint(strings.TrimPrefix(readSysfsString(path), "0x"), 16, 16)
+	if err != nil {
+		return 0
+	}
+	return uint16(value)
+}
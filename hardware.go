@@ -2,107 +2,551 @@ package main
 
 import (
 	"fmt"
+	"io"
+	"sort"
 	"strings"
+	"sync"
+
+	"github.com/mattn/go-runewidth"
 )
 
 type HardwareInfo struct {
-	CPU CPUInfo
+	CPU     CPUInfo     `yaml:"cpu"`
+	RAM     RAMInfo     `yaml:"ram"`
+	Disk    DiskInfo    `yaml:"disk"`
+	GPU     GPUInfo     `yaml:"gpu"`
+	Network NetworkInfo `yaml:"network"`
+	PCI     PCIInfo     `yaml:"pci"`
+	USB     USBInfo     `yaml:"usb"`
+	Sensors SensorInfo  `yaml:"sensors"`
+	System  SystemInfo  `yaml:"system"`
+	Stats   SystemStats `yaml:"stats"`
+	Battery BatteryInfo `yaml:"battery"`
+
+	// CollectionErrors records the non-critical collectors that failed;
+	// their sections are left empty.
+	CollectionErrors []error `yaml:"-" json:"-"`
 }
 
 type CPUInfo struct {
-	Vendor            string
-	Brand             string
-	Model             string
-	Family            uint32
-	ModelNumber       uint32
-	Stepping          uint32
-	Cores             uint32
-	Threads           uint32
-	Features          []string
-	FeatureCategories map[string][]FeatureDetail
-	CacheInfo         []string
-	CacheDetails      []CacheDetail
-	TLBInfo           TLBInfo
-	HybridInfo        HybridInfo
-	ProcessorInfo     ProcessorInfoDetail
-	ModelData         ModelDataDetail
-	MaxFunc           uint32
-	MaxExtFunc        uint32
-	PhysicalAddrBits  uint32
-	LinearAddrBits    uint32
+	Vendor            string                     `yaml:"vendor"`
+	Brand             string                     `yaml:"brand"`
+	Model             string                     `yaml:"model"`
+	Family            uint32                     `yaml:"family"`
+	ModelNumber       uint32                     `yaml:"model_number"`
+	Stepping          uint32                     `yaml:"stepping"`
+	Microcode         string                     `yaml:"microcode"`
+	Cores             uint32                     `yaml:"cores"`
+	Threads           uint32                     `yaml:"threads"`
+	ThreadsNote       string                     `yaml:"threads_note"` // Why Threads differs from CPUID's count, if it does
+	Features          []string                   `yaml:"features"`
+	FeatureCategories map[string][]FeatureDetail `yaml:"feature_categories"`
+	CacheInfo         []string                   `yaml:"cache_info"`
+	CacheDetails      []CacheDetail              `yaml:"cache_details"`
+	TLBInfo           TLBInfo                    `yaml:"tlb_info"`
+	HybridInfo        HybridInfo                 `yaml:"hybrid_info"`
+	ProcessorInfo     ProcessorInfoDetail        `yaml:"processor_info"`
+	ModelData         ModelDataDetail            `yaml:"model_data"`
+	MaxFunc           uint32                     `yaml:"max_func"`
+	MaxExtFunc        uint32                     `yaml:"max_ext_func"`
+	PhysicalAddrBits  uint32                     `yaml:"physical_addr_bits"`
+	LinearAddrBits    uint32                     `yaml:"linear_addr_bits"`
+	BaseMHz           uint32                     `yaml:"base_mhz"`
+	CurrentMHz        []uint32                   `yaml:"current_mhz"`
+	PackageTempC      float64                    `yaml:"package_temp_c"`
+	CoreTemps         []CoreTemp                 `yaml:"core_temps"`
+	GoArch            string                     `yaml:"go_arch"` // Architecture of this binary, not necessarily the CPU's
+	PointerBits       int                        `yaml:"pointer_bits"`
+	ByteOrder         string                     `yaml:"byte_order"`
+	NUMA              NUMAInfo                   `yaml:"numa"`
+
+	// RawCPUID holds every leaf's registers as returned, for the CPUID page
+	RawCPUID []CPUIDLeaf `yaml:"raw_cpuid"`
+
+	// RestrictedCPUID is set when CPUID reported no vendor or brand and
+	// Vendor or Brand holds a placeholder, as in some virtual machines.
+	RestrictedCPUID bool `yaml:"restricted_cpuid"`
+
+	// Hypervisor names the hypervisor the CPU reports running under, or is
+	// empty on bare metal.
+	Hypervisor string `yaml:"hypervisor"`
+
+	// FeatureDetails pairs each of Features with its details. It is only
+	// filled in for exports run with --descriptions.
+	FeatureDetails []FeatureDetail `yaml:"feature_details,omitempty" json:",omitempty"`
+}
+
+type CPUIDLeaf struct {
+	Leaf    uint32 `yaml:"leaf"`
+	Subleaf uint32 `yaml:"subleaf"`
+	EAX     uint32 `yaml:"eax"`
+	EBX     uint32 `yaml:"ebx"`
+	ECX     uint32 `yaml:"ecx"`
+	EDX     uint32 `yaml:"edx"`
+}
+
+// NUMAInfo lists the NUMA nodes. It is empty where the platform doesn't
+// report them; a single node means uniform memory access.
+type NUMAInfo struct {
+	Nodes []NUMANode `yaml:"nodes"`
+}
+
+type NUMANode struct {
+	Node        int    `yaml:"node"`
+	CPUs        string `yaml:"cpus"` // CPU list such as "0-15,32-47"
+	MemoryBytes uint64 `yaml:"memory_bytes"`
 }
 
 type FeatureDetail struct {
-	Name        string
-	Description string
-	Vendor      string
-	Category    string
+	Name        string `yaml:"name"`
+	Description string `yaml:"description"`
+	Vendor      string `yaml:"vendor"`
+	Category    string `yaml:"category"`
 }
 
 type CacheDetail struct {
-	Level            uint32
-	Type             string
-	SizeKB           uint32
-	Ways             uint32
-	LineSizeBytes    uint32
-	TotalSets        uint32
-	MaxCoresSharing  uint32
-	SelfInitializing bool
-	FullyAssociative bool
-	MaxProcessorIDs  uint32
-	WritePolicy      string
+	Level            uint32 `yaml:"level"`
+	Type             string `yaml:"type"`
+	SizeKB           uint32 `yaml:"size_kb"`
+	Ways             uint32 `yaml:"ways"`
+	LineSizeBytes    uint32 `yaml:"line_size_bytes"`
+	TotalSets        uint32 `yaml:"total_sets"`
+	MaxCoresSharing  uint32 `yaml:"max_cores_sharing"`
+	SelfInitializing bool   `yaml:"self_initializing"`
+	FullyAssociative bool   `yaml:"fully_associative"`
+	MaxProcessorIDs  uint32 `yaml:"max_processor_ids"`
+	WritePolicy      string `yaml:"write_policy"`
 }
 
 type TLBInfo struct {
-	L1Data    []TLBEntry
-	L1Inst    []TLBEntry
-	L2Unified []TLBEntry
+	L1Data    []TLBEntry `yaml:"l1_data"`
+	L1Inst    []TLBEntry `yaml:"l1_inst"`
+	L2Unified []TLBEntry `yaml:"l2_unified"`
+	L3Unified []TLBEntry `yaml:"l3_unified"`
 }
 
 type TLBEntry struct {
-	PageSize      string
-	Entries       int
-	Associativity string
+	PageSize      string `yaml:"page_size"`
+	Entries       int    `yaml:"entries"`
+	Associativity string `yaml:"associativity"`
 }
 
 type HybridInfo struct {
-	IsHybrid bool
-	CoreType string
+	IsHybrid bool   `yaml:"is_hybrid"`
+	CoreType string `yaml:"core_type"`
 }
 
 type ProcessorInfoDetail struct {
-	MaxLogicalProcessors uint32
-	InitialAPICID        uint32
-	PhysicalAddressBits  uint32
-	LinearAddressBits    uint32
-	CoreCount            uint32
-	ThreadPerCore        uint32
+	MaxLogicalProcessors uint32 `yaml:"max_logical_processors"`
+	InitialAPICID        uint32 `yaml:"initial_apic_id"`
+	PhysicalAddressBits  uint32 `yaml:"physical_address_bits"`
+	LinearAddressBits    uint32 `yaml:"linear_address_bits"`
+	CoreCount            uint32 `yaml:"core_count"`
+	ThreadPerCore        uint32 `yaml:"thread_per_core"`
 }
 
 type ModelDataDetail struct {
-	SteppingID       uint32
-	ModelID          uint32
-	FamilyID         uint32
-	ProcessorType    uint32
-	ExtendedModelID  uint32
-	ExtendedFamilyID uint32
-	ExtendedModel    uint32
-	ExtendedFamily   uint32
+	SteppingID       uint32 `yaml:"stepping_id"`
+	ModelID          uint32 `yaml:"model_id"`
+	FamilyID         uint32 `yaml:"family_id"`
+	ProcessorType    uint32 `yaml:"processor_type"`
+	ExtendedModelID  uint32 `yaml:"extended_model_id"`
+	ExtendedFamilyID uint32 `yaml:"extended_family_id"`
+	ExtendedModel    uint32 `yaml:"extended_model"`
+	ExtendedFamily   uint32 `yaml:"extended_family"`
+}
+
+type RAMInfo struct {
+	TotalBytes     uint64 `yaml:"total_bytes"`
+	AvailableBytes uint64 `yaml:"available_bytes"`
+	UsedBytes      uint64 `yaml:"used_bytes"`
+	FreeBytes      uint64 `yaml:"free_bytes"`
+	BuffersBytes   uint64 `yaml:"buffers_bytes"`
+	CachedBytes    uint64 `yaml:"cached_bytes"`
+	SwapTotalBytes uint64 `yaml:"swap_total_bytes"`
+	SwapFreeBytes  uint64 `yaml:"swap_free_bytes"`
+	// Modules lists every memory slot; empty slots have zero SizeBytes
+	Modules []MemoryModule `yaml:"modules"`
 }
 
+type MemoryModule struct {
+	Slot         string `yaml:"slot"`
+	Bank         string `yaml:"bank"`
+	SizeBytes    uint64 `yaml:"size_bytes"`
+	Type         string `yaml:"type"`
+	SpeedMTs     uint32 `yaml:"speed_mts"`
+	Manufacturer string `yaml:"manufacturer"`
+	PartNumber   string `yaml:"part_number"`
+}
+
+type DiskInfo struct {
+	Devices []DiskDevice `yaml:"devices"`
+}
+
+type DiskDevice struct {
+	Device     string `yaml:"device"`
+	MountPoint string `yaml:"mount_point"`
+	FSType     string `yaml:"fs_type"`
+	TotalBytes uint64 `yaml:"total_bytes"`
+	FreeBytes  uint64 `yaml:"free_bytes"`
+	UsedBytes  uint64 `yaml:"used_bytes"`
+	Health     string `yaml:"health"`
+}
+
+// SMART overall-health values stored in DiskDevice.Health.
+const (
+	diskHealthPassed  = "PASSED"
+	diskHealthFailed  = "FAILED"
+	diskHealthUnknown = "unknown"
+)
+
+type GPUInfo struct {
+	Devices []GPUDevice `yaml:"devices"`
+}
+
+type GPUDevice struct {
+	Card       string `yaml:"card"`
+	Vendor     string `yaml:"vendor"`
+	VendorID   uint16 `yaml:"vendor_id"`
+	DeviceID   uint16 `yaml:"device_id"`
+	Model      string `yaml:"model"`
+	Driver     string `yaml:"driver"`
+	PCIAddress string `yaml:"pci_address"`
+	VRAMBytes  uint64 `yaml:"vram_bytes"`
+}
+
+type NetworkInfo struct {
+	Interfaces []NetInterface `yaml:"interfaces"`
+}
+
+type NetInterface struct {
+	Name     string   `yaml:"name"`
+	MAC      string   `yaml:"mac"`
+	MTU      int      `yaml:"mtu"`
+	Up       bool     `yaml:"up"`
+	Loopback bool     `yaml:"loopback"`
+	Flags    string   `yaml:"flags"`
+	IPv4     []string `yaml:"ipv4"`
+	IPv6     []string `yaml:"ipv6"`
+}
+
+type PCIInfo struct {
+	Devices []PCIDevice `yaml:"devices"`
+}
+
+type PCIDevice struct {
+	Address   string `yaml:"address"`
+	VendorID  uint16 `yaml:"vendor_id"`
+	DeviceID  uint16 `yaml:"device_id"`
+	Class     uint32 `yaml:"class"` // 0xCCSSPP: class, subclass, programming interface
+	ClassName string `yaml:"class_name"`
+	Vendor    string `yaml:"vendor"` // Names are empty without a pci.ids database
+	Device    string `yaml:"device"`
+	Driver    string `yaml:"driver"`
+}
+
+type USBInfo struct {
+	Devices []USBDevice `yaml:"devices"`
+}
+
+type USBDevice struct {
+	Name         string `yaml:"name"` // sysfs name: "usb1" for a root hub, "1-2.4" for a port chain
+	Bus          int    `yaml:"bus"`
+	Depth        int    `yaml:"depth"` // Hubs between the device and its root hub, plus one
+	VendorID     uint16 `yaml:"vendor_id"`
+	ProductID    uint16 `yaml:"product_id"`
+	Manufacturer string `yaml:"manufacturer"`
+	Product      string `yaml:"product"`
+	Speed        string `yaml:"speed"` // Mbit/s, as sysfs reports it (1.5, 12, 480, 5000, ...)
+	Hub          bool   `yaml:"hub"`
+	RootHub      bool   `yaml:"root_hub"`
+}
+
+type SensorInfo struct {
+	Chips []SensorChip `yaml:"chips"`
+}
+
+// SensorChip is one hwmon device and its readings.
+type SensorChip struct {
+	Name    string   `yaml:"name"`   // Driver name, e.g. "coretemp" or "nct6798"
+	Device  string   `yaml:"device"` // hwmon device, e.g. "hwmon2"
+	Sensors []Sensor `yaml:"sensors"`
+}
+
+// CoreTemp is one per-core temperature reading, labelled as its hwmon
+// sensor is ("Core 4"). Core IDs can skip numbers, so the label rather than
+// the reading's position identifies the core.
+type CoreTemp struct {
+	Label string  `yaml:"label"`
+	TempC float64 `yaml:"temp_c"`
+}
+
+type Sensor struct {
+	Label string  `yaml:"label"`
+	Kind  string  `yaml:"kind"` // "temperature" (°C), "fan" (RPM) or "voltage" (V)
+	Value float64 `yaml:"value"`
+}
+
+type SystemInfo struct {
+	SystemVendor string `yaml:"system_vendor"`
+	ProductName  string `yaml:"product_name"`
+	BoardVendor  string `yaml:"board_vendor"`
+	BoardName    string `yaml:"board_name"`
+	BoardVersion string `yaml:"board_version"`
+	ChassisType  string `yaml:"chassis_type"`
+	BIOSVendor   string `yaml:"bios_vendor"`
+	BIOSVersion  string `yaml:"bios_version"`
+	BIOSDate     string `yaml:"bios_date"`
+}
+
+type BatteryInfo struct {
+	Batteries []BatteryDevice `yaml:"batteries"`
+}
+
+type BatteryDevice struct {
+	Name           string  `yaml:"name"`
+	Manufacturer   string  `yaml:"manufacturer"`
+	Model          string  `yaml:"model"`
+	ChargePercent  int     `yaml:"charge_percent"`
+	State          string  `yaml:"state"` // charging, discharging, full, not charging or unknown
+	DesignCapacity float64 `yaml:"design_capacity"`
+	FullCapacity   float64 `yaml:"full_capacity"`
+	CapacityUnit   string  `yaml:"capacity_unit"` // Wh or Ah, whichever the firmware reports
+	CycleCount     int     `yaml:"cycle_count"`
+}
+
+type SystemStats struct {
+	UptimeSeconds float64 `yaml:"uptime_seconds"`
+	Load1         float64 `yaml:"load_1"`
+	Load5         float64 `yaml:"load_5"`
+	Load15        float64 `yaml:"load_15"`
+}
+
+// DynamicInfo holds the readings that change while the program runs. Refresh
+// mode re-collects only these and leaves the rest of HardwareInfo alone.
+type DynamicInfo struct {
+	CurrentMHz   []uint32    `yaml:"current_mhz"`
+	PackageTempC float64     `yaml:"package_temp_c"`
+	CoreTemps    []CoreTemp  `yaml:"core_temps"`
+	RAM          RAMInfo     `yaml:"ram"`
+	Stats        SystemStats `yaml:"stats"`
+	Battery      BatteryInfo `yaml:"battery"`
+	Sensors      SensorInfo  `yaml:"sensors"`
+}
+
+// CollectHardwareInfo runs every collector in its own goroutine. Only a CPU
+// failure is fatal; the other collectors' errors are recorded in
+// CollectionErrors and their sections left empty.
 func CollectHardwareInfo() (*HardwareInfo, error) {
+	defer profileStep("total")()
 	info := &HardwareInfo{}
 
+	var (
+		wg      sync.WaitGroup
+		errMu   sync.Mutex
+		errs    []error
+		cpuErr  error
+		dynamic *DynamicInfo
+		modules []MemoryModule
+		numa    *NUMAInfo
+	)
+
+	// run starts collect in the background, recording its error under name.
+	// Each collector writes a different field of info, so they don't race.
+	run := func(name string, collect func() error) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer profileStep(name)()
+			if err := collect(); err != nil {
+				errMu.Lock()
+				errs = append(errs, fmt.Errorf("%s: %w", name, err))
+				errMu.Unlock()
+			}
+		}()
+	}
+
 	// Collect CPU info
-	cpuInfo, err := collectCPUInfo()
-	if err != nil {
-		return nil, fmt.Errorf("failed to collect CPU info: %w", err)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer profileStep("CPU")()
+		cpuInfo, partErrs, err := collectCPUInfo()
+		if err != nil {
+			cpuErr = err
+			return
+		}
+		info.CPU = *cpuInfo
+		errMu.Lock()
+		errs = append(errs, partErrs...)
+		errMu.Unlock()
+	}()
+
+	// Collect disk info
+	run("disk", func() error {
+		diskInfo, err := collectDiskInfo()
+		if err != nil {
+			return err
+		}
+		info.Disk = *diskInfo
+		return nil
+	})
+
+	// Collect GPU info
+	run("GPU", func() error {
+		gpuInfo, err := collectGPUInfo()
+		if err != nil {
+			return err
+		}
+		info.GPU = *gpuInfo
+		return nil
+	})
+
+	// Collect network info
+	run("network", func() error {
+		networkInfo, err := collectNetworkInfo()
+		if err != nil {
+			return err
+		}
+		info.Network = *networkInfo
+		return nil
+	})
+
+	// Collect PCI devices
+	run("pci", func() error {
+		pciInfo, err := collectPCIInfo()
+		if err != nil {
+			return err
+		}
+		info.PCI = *pciInfo
+		return nil
+	})
+
+	// Collect USB devices
+	run("usb", func() error {
+		usbInfo, err := collectUSBInfo()
+		if err != nil {
+			return err
+		}
+		info.USB = *usbInfo
+		return nil
+	})
+
+	// Collect motherboard and BIOS info
+	run("system", func() error {
+		info.System = *collectSystemInfo()
+		return nil
+	})
+
+	// Collect memory modules (needs root)
+	run("memory modules", func() error {
+		var err error
+		modules, err = collectMemoryModules()
+		return err
+	})
+
+	// Collect NUMA nodes, which land in info.CPU like the readings below
+	run("numa", func() error {
+		var err error
+		numa, err = collectNUMAInfo()
+		return err
+	})
+
+	// Collect clock speeds, temperatures and memory usage. These land in
+	// info.CPU too, so they're applied once the CPU collector is done.
+	run("dynamic", func() error {
+		dynamic = collectDynamicInfo()
+		return nil
+	})
+
+	wg.Wait()
+
+	if cpuErr != nil {
+		return nil, fmt.Errorf("failed to collect CPU info: %w", cpuErr)
+	}
+	info.applyDynamic(dynamic)
+	info.RAM.Modules = modules
+	if numa != nil {
+		info.CPU.NUMA = *numa
 	}
-	info.CPU = *cpuInfo
+
+	sort.Slice(errs, func(i, j int) bool { return errs[i].Error() < errs[j].Error() })
+	info.CollectionErrors = errs
 
 	return info, nil
 }
 
+// writeCollectionErrors prints the collectors that failed, one per line,
+// when --debug is set.
+func writeCollectionErrors(w io.Writer, errs []error) {
+	if !debugCollection {
+		return
+	}
+	for _, err := range errs {
+		fmt.Fprintf(w, "debug: %v\n", err)
+	}
+}
+
+// collectDynamicInfo collects the readings that change from moment to
+// moment. It never fails; readings that aren't available are left empty.
+func collectDynamicInfo() *DynamicInfo {
+	dynamic := &DynamicInfo{
+		CurrentMHz: collectCurrentMHz(),
+	}
+	dynamic.PackageTempC, dynamic.CoreTemps = collectCPUTemp()
+
+	// RAM info is not available on every platform
+	ramInfo, err := collectRAMInfo()
+	if err == nil {
+		dynamic.RAM = *ramInfo
+	}
+
+	// Nor are uptime and load
+	stats, err := collectSystemStats()
+	if err == nil {
+		dynamic.Stats = *stats
+	}
+
+	dynamic.Battery = *collectBatteryInfo()
+	dynamic.Sensors = *collectSensorInfo()
+
+	return dynamic
+}
+
+// applyDynamic overwrites info's dynamic readings with those in dynamic.
+func (info *HardwareInfo) applyDynamic(dynamic *DynamicInfo) {
+	info.CPU.CurrentMHz = dynamic.CurrentMHz
+	info.CPU.PackageTempC = dynamic.PackageTempC
+	info.CPU.CoreTemps = dynamic.CoreTemps
+	modules := info.RAM.Modules
+	info.RAM = dynamic.RAM
+	info.RAM.Modules = modules // Static; only collected once
+	info.Stats = dynamic.Stats
+	info.Battery = dynamic.Battery
+	info.Sensors = dynamic.Sensors
+}
+
+// featureDetailIndex maps feature names to their details. Categories are
+// visited in sorted order so a name listed under several categories
+// resolves the same way every time.
+func featureDetailIndex(byCategory map[string][]FeatureDetail) map[string]FeatureDetail {
+	categories := make([]string, 0, len(byCategory))
+	for category := range byCategory {
+		categories = append(categories, category)
+	}
+	sort.Strings(categories)
+
+	index := make(map[string]FeatureDetail)
+	for _, category := range categories {
+		for _, detail := range byCategory[category] {
+			if _, seen := index[detail.Name]; !seen {
+				index[detail.Name] = detail
+			}
+		}
+	}
+	return index
+}
+
 func formatBytes(bytes uint64) string {
 	const unit = 1024
 	if bytes < unit {
@@ -116,14 +560,29 @@ func formatBytes(bytes uint64) string {
 	return fmt.Sprintf("%.2f %cB", float64(bytes)/float64(div), "KMGTPE"[exp])
 }
 
+// formatPercent returns used as a percentage of total, or "n/a" when the
+// total is zero (tmpfs, overlay and other size-less filesystems).
+func formatPercent(used, total uint64) string {
+	if total == 0 {
+		return "n/a"
+	}
+	return fmt.Sprintf("%.1f%%", float64(used)*100/float64(total))
+}
+
+// truncateString shortens s to at most maxLen display columns, appending
+// "..." when it cuts and there is room for it. Wide runes such as CJK take
+// two columns and are dropped whole rather than split.
 func truncateString(s string, maxLen int) string {
-	if len(s) <= maxLen {
+	if maxLen <= 0 {
+		return ""
+	}
+	if runewidth.StringWidth(s) <= maxLen {
 		return s
 	}
 	if maxLen <= 3 {
-		return s[:maxLen]
+		return runewidth.Truncate(s, maxLen, "")
 	}
-	return s[:maxLen-3] + "..."
+	return runewidth.Truncate(s, maxLen, "...")
 }
 
 func wrapText(text string, width int) []string {
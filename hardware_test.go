@@ -0,0 +1,45 @@
+package main
+
+import (
+	"testing"
+	"unicode/utf8"
+
+	"github.com/mattn/go-runewidth"
+)
+
+func TestTruncateString(t *testing.T) {
+	tests := []struct {
+		name   string
+		s      string
+		maxLen int
+		want   string
+	}{
+		{"fits", "Intel", 5, "Intel"},
+		{"ascii cut", "Intel Core", 8, "Intel..."},
+		{"zero width", "Intel", 0, ""},
+		{"short limit", "Intel", 3, "Int"},
+		{"cjk fits", "處理器", 6, "處理器"},
+		{"cjk cut on boundary", "處理器晶片", 7, "處理..."},
+		{"cjk cut mid rune", "處理器晶片", 8, "處理..."},
+		{"cjk short limit even", "處理器", 2, "處"},
+		{"cjk short limit odd", "處理器", 3, "處"},
+		{"cjk single column", "處理器", 1, ""},
+		{"emoji cut", "🔥🔥🔥🔥", 6, "🔥..."},
+		{"emoji short limit", "🔥🔥", 3, "🔥"},
+		{"mixed", "CPU 🔥 溫度", 9, "CPU 🔥..."},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := truncateString(tt.s, tt.maxLen)
+			if got != tt.want {
+				t.Errorf("truncateString(%q, %d) = %q, want %q", tt.s, tt.maxLen, got, tt.want)
+			}
+			if !utf8.ValidString(got) {
+				t.Errorf("truncateString(%q, %d) split a rune: %q", tt.s, tt.maxLen, got)
+			}
+			if w := runewidth.StringWidth(got); w > max(tt.maxLen, 0) {
+				t.Errorf("truncateString(%q, %d) is %d columns wide", tt.s, tt.maxLen, w)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var hasVerbose bool
+
+var hasCmd = &cobra.Command{
+	Use:   "has <feature>",
+	Short: "Exit 0 if the CPU supports a feature, 1 if not",
+	Long: "Checks whether the CPU supports a feature, for gating scripts: earhw has avx512f && ./run-optimized. " +
+		"Names match ignoring case and _, - and . separators, and Linux /proc/cpuinfo names such as sse4_2 or sha_ni are accepted. " +
+		"Prints nothing unless -v is given. Exits 2 if the CPU can't be read.",
+	Args: cobra.ExactArgs(1),
+	Run:  runHas,
+}
+
+func init() {
+	hasCmd.Flags().BoolVarP(&hasVerbose, "verbose", "v", false, "Print whether the feature is supported")
+	rootCmd.AddCommand(hasCmd)
+}
+
+// featureAliases maps normalized /proc/cpuinfo flag names to the
+// normalized names cpuid uses, where they differ by more than separators.
+var featureAliases = map[string]string{
+	"PNI":              "SSE3",
+	"PCLMUL":           "PCLMULQDQ",
+	"SHANI":            "SHA",
+	"CX16":             "CMPXCHG16B",
+	"CLFLUSH":          "CLFSH",
+	"HT":               "HTT",
+	"PDPE1GB":          "1GBPAGE",
+	"TSCDEADLINETIMER": "TSCDEADLINE",
+	"CONSTANTTSC":      "TSCINVARIANT",
+	"NONSTOPTSC":       "TSCINVARIANT",
+	"ARCHCAPABILITIES": "IA32ARCHCAPS",
+}
+
+func runHas(cmd *cobra.Command, args []string) {
+	cpu, _, err := collectCPUInfo()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error collecting CPU info: %v\n", err)
+		os.Exit(2)
+	}
+
+	name, ok := findFeature(cpu.Features, args[0])
+	if hasVerbose {
+		if ok {
+			fmt.Printf("%s: supported\n", name)
+		} else {
+			fmt.Printf("%s: not supported\n", args[0])
+		}
+	}
+	if !ok {
+		os.Exit(1)
+	}
+}
+
+// findFeature returns the entry of features that query names, comparing
+// normalized names and resolving aliases.
+func findFeature(features []string, query string) (string, bool) {
+	want := normalizeFeature(query)
+	if alias, ok := featureAliases[want]; ok {
+		want = alias
+	}
+	for _, feature := range features {
+		if normalizeFeature(feature) == want {
+			return feature, true
+		}
+	}
+	return "", false
+}
+
+// normalizeFeature uppercases a feature name and drops the separators that
+// vary between naming schemes, so sse4_2, SSE4.2 and sse4-2 all compare
+// equal.
+func normalizeFeature(name string) string {
+	return strings.ToUpper(strings.NewReplacer("_", "", "-", "", ".", "", " ", "").Replace(name))
+}
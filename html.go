@@ -0,0 +1,96 @@
+package main
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var htmlCmd = &cobra.Command{
+	Use:   "html",
+	Short: "Print a hardware report as a self-contained HTML page",
+	Long: "Collects hardware information and prints it as a single HTML page with inline styles and no external assets, " +
+		"so it can be emailed or opened anywhere.",
+	Args: cobra.NoArgs,
+	Run:  runHTML,
+}
+
+func init() {
+	rootCmd.AddCommand(htmlCmd)
+}
+
+func runHTML(cmd *cobra.Command, args []string) {
+	hwInfo := collectForExport()
+
+	err := writeOutput(outputPath, func(w io.Writer) error {
+		return writeHTMLReport(w, hwInfo)
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing HTML: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// htmlReport is the data the HTML template renders, in page order.
+type htmlReport struct {
+	Title    string
+	Sections []reportSection
+	Tables   []reportTable
+	Devices  []reportSection
+}
+
+// htmlTemplate escapes every value through html/template; the only markup
+// is the template's own.
+var htmlTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"text": func(s string) string { return strings.Join(strings.Fields(s), " ") },
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; color: #222; max-width: 60em; margin: 2em auto; padding: 0 1em; }
+h1 { font-size: 1.6em; border-bottom: 2px solid #4a7bd0; padding-bottom: 0.3em; }
+h2 { font-size: 1.2em; color: #4a7bd0; margin-top: 1.6em; }
+ul { list-style: none; padding-left: 0; margin: 0; }
+li { padding: 0.15em 0; }
+li.heading { font-weight: bold; margin-top: 0.4em; }
+table { border-collapse: collapse; width: 100%; }
+th, td { text-align: left; vertical-align: top; padding: 0.3em 0.6em; border: 1px solid #ddd; }
+th { background: #eef2fa; }
+tr:nth-child(even) td { background: #fafafa; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+{{define "section"}}<h2>{{.Title}}</h2>
+<ul>
+{{range .Lines}}<li{{if .Heading}} class="heading"{{end}}{{if .Indent}} style="margin-left: {{.Indent}}em"{{end}}>{{text .Text}}</li>
+{{end}}</ul>
+{{end}}{{range .Sections}}{{template "section" .}}{{end}}
+{{- range .Tables}}<h2>{{.Title}}</h2>
+<table>
+<tr>{{range .Header}}<th>{{.}}</th>{{end}}</tr>
+{{range .Rows}}<tr>{{range .}}<td>{{.}}</td>{{end}}</tr>
+{{end}}</table>
+{{end}}
+{{- range .Devices}}{{template "section" .}}{{end -}}
+</body>
+</html>
+`))
+
+// writeHTMLReport writes the same sections and tables as the Markdown
+// export as a standalone HTML page.
+func writeHTMLReport(w io.Writer, hwInfo *HardwareInfo) error {
+	return htmlTemplate.Execute(w, htmlReport{
+		Title:    "Hardware Report: " + hwInfo.CPU.Brand,
+		Sections: cpuDetailSections(&hwInfo.CPU),
+		Tables:   cpuTables(&hwInfo.CPU),
+		Devices:  deviceSections(hwInfo, selectedGlyphs()),
+	})
+}
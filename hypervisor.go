@@ -0,0 +1,54 @@
+package main
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strings"
+
+	"github.com/earentir/cpuid"
+)
+
+// hypervisorNames maps the vendor signatures hypervisors return in EBX, ECX
+// and EDX of leaf 0x40000000 to their names.
+var hypervisorNames = map[string]string{
+	"KVMKVMKVM\x00\x00\x00": "KVM",
+	"Linux KVM Hv":          "KVM",
+	"VMwareVMware":          "VMware",
+	"Microsoft Hv":          "Hyper-V",
+	"XenVMMXenVMM":          "Xen",
+	"TCGTCGTCGTCG":          "QEMU",
+	"VBoxVBoxVBox":          "VirtualBox",
+	" lrpepyh  vr":          "Parallels",
+	"bhyve bhyve ":          "bhyve",
+	"ACRNACRNACRN":          "ACRN",
+	"QNXQVMBSQG":            "QNX",
+	"Apple VZ":              "Apple Virtualization",
+}
+
+// collectHypervisor returns the name of the hypervisor the CPU reports
+// running under, or "" on bare metal. The hypervisor-present bit (leaf 1,
+// ECX bit 31) is set by every mainstream hypervisor; an unrecognised vendor
+// signature is shown as is.
+func collectHypervisor() string {
+	_, _, ecx, _ := cpuid.CPUIDWithMode(1, 0, false, "")
+	if ecx&(1<<31) == 0 {
+		return ""
+	}
+
+	_, ebx, ecx, edx := cpuid.CPUIDWithMode(0x40000000, 0, false, "")
+	signature := make([]byte, 12)
+	binary.LittleEndian.PutUint32(signature[0:], ebx)
+	binary.LittleEndian.PutUint32(signature[4:], ecx)
+	binary.LittleEndian.PutUint32(signature[8:], edx)
+
+	if name, ok := hypervisorNames[string(signature)]; ok {
+		return name
+	}
+	if name, ok := hypervisorNames[strings.TrimRight(string(signature), "\x00")]; ok {
+		return name
+	}
+	if trimmed := strings.Trim(string(signature), " \x00"); trimmed != "" {
+		return fmt.Sprintf("Unknown hypervisor (%q)", trimmed)
+	}
+	return "Unknown hypervisor"
+}
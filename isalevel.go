@@ -0,0 +1,113 @@
+package main
+
+import "slices"
+
+// isaLevels are the x86-64 microarchitecture levels of the psABI, each
+// requiring its flags on top of the levels before it. OSFXSR, which v1 also
+// lists, is a control register bit rather than a CPUID flag and is left out.
+var isaLevels = []struct {
+	name  string
+	flags []string
+}{
+	{"x86-64-v1", []string{"CMOV", "CX8", "FPU", "FXSR", "MMX", "SYSCALL", "SSE", "SSE2"}},
+	{"x86-64-v2", []string{"CMPXCHG16B", "LAHF_LM", "POPCNT", "SSE3", "SSE4.1", "SSE4.2", "SSSE3"}},
+	{"x86-64-v3", []string{"AVX", "AVX2", "BMI1", "BMI2", "F16C", "FMA", "LZCNT", "MOVBE", "OSXSAVE"}},
+	{"x86-64-v4", []string{"AVX512F", "AVX512BW", "AVX512CD", "AVX512DQ", "AVX512VL"}},
+}
+
+// cpuidBit locates a feature flag in the CPUID registers.
+type cpuidBit struct {
+	leaf uint32
+	reg  byte // 'a' to 'd' for EAX to EDX
+	bit  uint
+}
+
+// isaFlagBits locates the flags of isaLevels. They are read from the raw
+// leaves rather than Features because cpuid's feature tables list some of
+// them only for one vendor, and number a few of them off by one.
+var isaFlagBits = map[string]cpuidBit{
+	"FPU":        {0x1, 'd', 0},
+	"CX8":        {0x1, 'd', 8},
+	"CMOV":       {0x1, 'd', 15},
+	"MMX":        {0x1, 'd', 23},
+	"FXSR":       {0x1, 'd', 24},
+	"SSE":        {0x1, 'd', 25},
+	"SSE2":       {0x1, 'd', 26},
+	"SSE3":       {0x1, 'c', 0},
+	"SSSE3":      {0x1, 'c', 9},
+	"FMA":        {0x1, 'c', 12},
+	"CMPXCHG16B": {0x1, 'c', 13},
+	"SSE4.1":     {0x1, 'c', 19},
+	"SSE4.2":     {0x1, 'c', 20},
+	"MOVBE":      {0x1, 'c', 22},
+	"POPCNT":     {0x1, 'c', 23},
+	"OSXSAVE":    {0x1, 'c', 27},
+	"AVX":        {0x1, 'c', 28},
+	"F16C":       {0x1, 'c', 29},
+	"BMI1":       {0x7, 'b', 3},
+	"AVX2":       {0x7, 'b', 5},
+	"BMI2":       {0x7, 'b', 8},
+	"AVX512F":    {0x7, 'b', 16},
+	"AVX512DQ":   {0x7, 'b', 17},
+	"AVX512CD":   {0x7, 'b', 28},
+	"AVX512BW":   {0x7, 'b', 30},
+	"AVX512VL":   {0x7, 'b', 31},
+	"LAHF_LM":    {0x80000001, 'c', 0},
+	"LZCNT":      {0x80000001, 'c', 5},
+	"SYSCALL":    {0x80000001, 'd', 11},
+}
+
+// isaLevel returns the highest x86-64 level the CPU satisfies, or "" when
+// it doesn't meet v1, as on other architectures.
+func isaLevel(cpu *CPUInfo) string {
+	flags := isaFlags(cpu)
+	level := ""
+	for _, l := range isaLevels {
+		for _, flag := range l.flags {
+			if !flags[flag] {
+				return level
+			}
+		}
+		level = l.name
+	}
+	return level
+}
+
+// isaFlags returns the set of isaLevels flags the CPU has, decoded from
+// RawCPUID, or taken from Features for snapshots saved without it.
+func isaFlags(cpu *CPUInfo) map[string]bool {
+	flags := make(map[string]bool)
+	if len(cpu.RawCPUID) == 0 {
+		for _, l := range isaLevels {
+			for _, flag := range l.flags {
+				flags[flag] = slices.Contains(cpu.Features, flag)
+			}
+		}
+		return flags
+	}
+
+	for name, loc := range isaFlagBits {
+		i := slices.IndexFunc(cpu.RawCPUID, func(leaf CPUIDLeaf) bool {
+			return leaf.Leaf == loc.leaf && leaf.Subleaf == 0
+		})
+		if i < 0 {
+			continue
+		}
+		flags[name] = cpu.RawCPUID[i].register(loc.reg)&(1<<loc.bit) != 0
+	}
+	return flags
+}
+
+// register returns EAX, EBX, ECX or EDX for reg 'a' to 'd'.
+func (l CPUIDLeaf) register(reg byte) uint32 {
+	switch reg {
+	case 'a':
+		return l.EAX
+	case 'b':
+		return l.EBX
+	case 'c':
+		return l.ECX
+	default:
+		return l.EDX
+	}
+}
@@ -3,15 +3,83 @@ package main
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 )
 
+// Build information, set with -ldflags "-X main.version=... -X main.commit=... -X main.date=...".
+var (
+	version = "dev"
+	commit  = "unknown"
+	date    = "unknown"
+)
+
 var rootCmd = &cobra.Command{
 	Use:   "earhw",
 	Short: "Hardware information tool with TUI",
 	Long:  "A hardware information tool that displays CPU, RAM, and disk information in a retro-style TUI interface.",
 	Run:   runTUI,
+
+	// main prints the error Execute returns; cobra printing it too would
+	// report every error twice
+	SilenceErrors: true,
+
+	// The config only sets the TUI's own flags, so a bad file doesn't
+	// stop subcommands such as completion or version
+	PreRunE: loadConfig,
+}
+
+var (
+	refreshSeconds  int
+	fromPath        string
+	startPage       string
+	themeName       string
+	noColor         bool
+	tempWarnC       float64
+	asciiOnly       bool
+	refreshOnFocus  bool
+	forceTUI        bool
+	noTUI           bool
+	featureColumns  int
+	debugCollection bool
+	noWrap          bool
+	pinnedCPU       int
+	showSplash      bool
+	noMouse         bool
+
+	profileCollection bool
+)
+
+func init() {
+	rootCmd.Version = version
+	rootCmd.SetVersionTemplate(versionString() + "\n")
+	rootCmd.Flags().IntVar(&refreshSeconds, "refresh", 0, "Re-read clock speeds, temperatures and other sensors, memory usage and battery charge every N seconds (0 disables)")
+	rootCmd.Flags().BoolVar(&refreshOnFocus, "refresh-on-focus", false, "Re-read clock speeds, temperatures and other sensors, memory usage and battery charge when the terminal regains focus")
+	rootCmd.Flags().StringVar(&themeName, "theme", "classic", "Color theme ("+strings.Join(themeNames(), ", ")+")")
+	rootCmd.Flags().BoolVar(&noColor, "no-color", false, "Disable colors (also enabled by the NO_COLOR environment variable)")
+	rootCmd.PersistentFlags().BoolVar(&asciiOnly, "ascii", false, "Draw borders and symbols with plain ASCII instead of Unicode, in the TUI and the text, Markdown and HTML reports")
+	rootCmd.Flags().Float64Var(&tempWarnC, "temp-warn", 85, "Highlight CPU temperatures at or above this many degrees Celsius")
+	rootCmd.Flags().IntVar(&featureColumns, "feature-columns", 0, "Columns in the feature grids (0 picks as many as fit, up to 4)")
+	rootCmd.Flags().BoolVar(&showSplash, "splash", false, "Show a boot screen with the CPU brand before the first page")
+	rootCmd.Flags().BoolVar(&noMouse, "no-mouse", false, "Leave the mouse to the terminal, so text can be selected and copied")
+	rootCmd.Flags().BoolVar(&noWrap, "no-wrap", false, "Stop at the first and last page instead of wrapping around")
+	rootCmd.Flags().StringVar(&fromPath, "from", "", "Show a file written by the snapshot command instead of this machine")
+	rootCmd.Flags().BoolVar(&forceTUI, "tui", false, "Start the TUI even when stdout is not a terminal")
+	rootCmd.Flags().BoolVar(&noTUI, "no-tui", false, "Print the plain-text report instead of starting the TUI")
+	rootCmd.MarkFlagsMutuallyExclusive("tui", "no-tui")
+	rootCmd.Flags().BoolVar(&profileCollection, "profile-collection", false, "Print how long each collection step took to stderr (only that, with --no-tui)")
+	rootCmd.Flags().MarkHidden("profile-collection")
+	// A snapshot's readings can't be refreshed or its collection timed
+	rootCmd.MarkFlagsMutuallyExclusive("from", "refresh")
+	rootCmd.MarkFlagsMutuallyExclusive("from", "refresh-on-focus")
+	rootCmd.MarkFlagsMutuallyExclusive("from", "profile-collection")
+	rootCmd.PersistentFlags().BoolVar(&debugCollection, "debug", false, "Print why any collector failed to stderr, naming the part left empty")
+	rootCmd.PersistentFlags().IntVar(&pinnedCPU, "cpu", -1, "Read CPUID on this logical CPU, e.g. to compare P- and E-cores (Linux only; elsewhere the current CPU)")
+	rootCmd.PersistentFlags().StringVar(&startPage, "page", "summary", "Page to open the TUI on ("+strings.Join(pageNameList(), ", ")+")")
+
+	rootCmd.RegisterFlagCompletionFunc("theme", completeValues(themeNames()))
+	rootCmd.RegisterFlagCompletionFunc("page", completeValues(pageNameList()))
 }
 
 func main() {
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var markdownCmd = &cobra.Command{
+	Use:   "markdown",
+	Short: "Print a hardware report as Markdown",
+	Long: "Collects hardware information and prints it as Markdown, with a ## header per section and tables for the caches, TLBs and features, " +
+		"for pasting into inventory wikis.",
+	Args: cobra.NoArgs,
+	Run:  runMarkdown,
+}
+
+func init() {
+	rootCmd.AddCommand(markdownCmd)
+}
+
+func runMarkdown(cmd *cobra.Command, args []string) {
+	hwInfo := collectForExport()
+
+	err := writeOutput(outputPath, func(w io.Writer) error {
+		return writeMarkdownReport(w, hwInfo)
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing Markdown: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// writeMarkdownReport writes the CPU sections, then the cache, TLB and
+// feature tables, then the other pages' sections.
+func writeMarkdownReport(w io.Writer, hwInfo *HardwareInfo) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Hardware Report: %s\n", markdownEscape(hwInfo.CPU.Brand))
+
+	for _, section := range cpuDetailSections(&hwInfo.CPU) {
+		writeMarkdownSection(&b, section)
+	}
+
+	for _, table := range cpuTables(&hwInfo.CPU) {
+		writeMarkdownTable(&b, table)
+	}
+
+	for _, section := range deviceSections(hwInfo, selectedGlyphs()) {
+		writeMarkdownSection(&b, section)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// writeMarkdownSection writes a section as a bulleted list, nesting
+// indented lines and setting sub-headings in bold.
+func writeMarkdownSection(b *strings.Builder, section reportSection) {
+	fmt.Fprintf(b, "\n## %s\n\n", markdownEscape(section.Title))
+	for _, line := range section.Lines {
+		// Collapse the padding that aligns values on screen
+		text := markdownEscape(strings.Join(strings.Fields(line.Text), " "))
+		if line.Heading {
+			text = "**" + text + "**"
+		}
+		fmt.Fprintf(b, "%s- %s\n", strings.Repeat("  ", line.Indent), text)
+	}
+}
+
+// writeMarkdownTable writes a titled table with a header row.
+func writeMarkdownTable(b *strings.Builder, table reportTable) {
+	fmt.Fprintf(b, "\n## %s\n\n", markdownEscape(table.Title))
+	writeRow := func(cells []string) {
+		escaped := make([]string, len(cells))
+		for i, cell := range cells {
+			escaped[i] = markdownEscape(cell)
+		}
+		fmt.Fprintf(b, "| %s |\n", strings.Join(escaped, " | "))
+	}
+	writeRow(table.Header)
+	fmt.Fprintf(b, "|%s\n", strings.Repeat(" --- |", len(table.Header)))
+	for _, row := range table.Rows {
+		writeRow(row)
+	}
+}
+
+// markdownEscape escapes backslashes and pipes, which would end a table
+// cell, and folds newlines into spaces so a value stays on its line.
+func markdownEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "|", `\|`, "\r\n", " ", "\n", " ").Replace(s)
+}
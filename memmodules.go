@@ -0,0 +1,127 @@
+package main
+
+import (
+	"encoding/binary"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// smbiosMemoryTypes names the SMBIOS type 17 "Memory Type" codes seen on
+// current hardware.
+var smbiosMemoryTypes = map[byte]string{
+	0x07: "RAM",
+	0x0F: "SDRAM",
+	0x12: "DDR",
+	0x13: "DDR2",
+	0x18: "DDR3",
+	0x1A: "DDR4",
+	0x1B: "LPDDR",
+	0x1C: "LPDDR2",
+	0x1D: "LPDDR3",
+	0x1E: "LPDDR4",
+	0x22: "DDR5",
+	0x23: "LPDDR5",
+}
+
+// collectMemoryModules decodes the SMBIOS type 17 (Memory Device) entries
+// the kernel exposes under /sys/firmware/dmi/entries. They are readable by
+// root only, so for other users this returns an error.
+func collectMemoryModules() ([]MemoryModule, error) {
+	entries, _ := filepath.Glob("/sys/firmware/dmi/entries/17-*")
+	if len(entries) == 0 {
+		return nil, errors.New("no SMBIOS memory device entries")
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return dmiEntryIndex(entries[i]) < dmiEntryIndex(entries[j])
+	})
+
+	var modules []MemoryModule
+	var lastErr error
+	for _, entry := range entries {
+		raw, err := os.ReadFile(filepath.Join(entry, "raw"))
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if module, ok := parseMemoryDevice(raw); ok {
+			modules = append(modules, module)
+		}
+	}
+	if len(modules) == 0 && lastErr != nil {
+		return nil, lastErr
+	}
+	return modules, nil
+}
+
+// dmiEntryIndex extracts N from a "17-N" entry directory name.
+func dmiEntryIndex(path string) int {
+	_, n, _ := strings.Cut(filepath.Base(path), "-")
+	index, _ := strconv.Atoi(n)
+	return index
+}
+
+// parseMemoryDevice decodes one raw SMBIOS type 17 structure: the
+// formatted area described by its length byte, then its string set. Entries
+// shorter than SMBIOS 2.1's 0x15 bytes are rejected as malformed.
+func parseMemoryDevice(raw []byte) (MemoryModule, bool) {
+	if len(raw) < 2 || raw[0] != 17 || raw[1] < 0x15 || int(raw[1]) > len(raw) {
+		return MemoryModule{}, false
+	}
+	formatted := raw[:raw[1]]
+	strs := strings.Split(string(raw[raw[1]:]), "\x00")
+	str := func(offset int) string {
+		if offset >= len(formatted) {
+			return ""
+		}
+		index := int(formatted[offset])
+		if index == 0 || index > len(strs) {
+			return ""
+		}
+		return strings.TrimSpace(strs[index-1])
+	}
+	word := func(offset int) uint16 {
+		if offset+2 > len(formatted) {
+			return 0
+		}
+		return binary.LittleEndian.Uint16(formatted[offset:])
+	}
+	dword := func(offset int) uint32 {
+		if offset+4 > len(formatted) {
+			return 0
+		}
+		return binary.LittleEndian.Uint32(formatted[offset:])
+	}
+
+	module := MemoryModule{
+		Slot: str(0x10),
+		Bank: str(0x11),
+	}
+
+	// Size: 0 means no module installed, 0xFFFF unknown, 0x7FFF that the
+	// real size (in MB) is in the extended size field. Bit 15 selects KB
+	// instead of MB.
+	switch size := word(0x0C); {
+	case size == 0 || size == 0xFFFF:
+		return module, true
+	case size == 0x7FFF:
+		module.SizeBytes = uint64(dword(0x1C)&0x7FFFFFFF) << 20
+	case size&0x8000 != 0:
+		module.SizeBytes = uint64(size&0x7FFF) << 10
+	default:
+		module.SizeBytes = uint64(size) << 20
+	}
+
+	module.Type = smbiosMemoryTypes[formatted[0x12]]
+	module.SpeedMTs = uint32(word(0x15))
+	if module.SpeedMTs == 0xFFFF {
+		module.SpeedMTs = dword(0x54)
+	}
+	module.Manufacturer = str(0x17)
+	module.PartNumber = str(0x1A)
+
+	return module, true
+}
@@ -0,0 +1,42 @@
+package main
+
+import "testing"
+
+// memoryDevice builds a type 17 entry with a formatted area of length bytes
+// holding a 16 GB DDR4 module, followed by the string set.
+func memoryDevice(length byte) []byte {
+	raw := make([]byte, length)
+	raw[0], raw[1] = 17, length
+	set := func(offset int, b ...byte) {
+		if offset+len(b) <= len(raw) {
+			copy(raw[offset:], b)
+		}
+	}
+	set(0x0C, 0x00, 0x40) // 16384 MB
+	set(0x10, 1)          // Slot: string 1
+	set(0x12, 0x1A)       // DDR4
+	set(0x15, 0x80, 0x0C) // 3200 MT/s
+	return append(raw, "DIMM_A1\x00\x00"...)
+}
+
+func TestParseMemoryDevice(t *testing.T) {
+	module, ok := parseMemoryDevice(memoryDevice(0x1B))
+	if !ok {
+		t.Fatal("parseMemoryDevice rejected a valid entry")
+	}
+	want := MemoryModule{Slot: "DIMM_A1", SizeBytes: 16 << 30, Type: "DDR4", SpeedMTs: 3200}
+	if module != want {
+		t.Errorf("parseMemoryDevice() = %+v, want %+v", module, want)
+	}
+}
+
+func TestParseMemoryDeviceTruncated(t *testing.T) {
+	for _, length := range []byte{0x02, 0x0E, 0x12, 0x14} {
+		if module, ok := parseMemoryDevice(memoryDevice(length)); ok {
+			t.Errorf("length 0x%02X: parseMemoryDevice() = %+v, want it rejected", length, module)
+		}
+	}
+	if _, ok := parseMemoryDevice([]byte{17}); ok {
+		t.Error("parseMemoryDevice accepted an entry without a length byte")
+	}
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"bufio"
+	"os"
+	"strings"
+)
+
+// collectMicrocode returns the running microcode revision (e.g. "0xf4") as
+// Linux reports it, preferring sysfs over /proc/cpuinfo. It returns "" when
+// neither is available, as on other platforms.
+func collectMicrocode() string {
+	data, err := os.ReadFile("/sys/devices/system/cpu/cpu0/microcode/version")
+	if err == nil {
+		if version := strings.TrimSpace(string(data)); version != "" {
+			return version
+		}
+	}
+
+	f, err := os.Open("/proc/cpuinfo")
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+
+	// Every CPU has a block; the first one is enough
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), ":")
+		if ok && strings.TrimSpace(key) == "microcode" {
+			return strings.TrimSpace(value)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"net"
+)
+
+func collectNetworkInfo() (*NetworkInfo, error) {
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		return nil, err
+	}
+
+	info := &NetworkInfo{}
+	for _, iface := range ifaces {
+		netIface := NetInterface{
+			Name:     iface.Name,
+			MAC:      iface.HardwareAddr.String(),
+			MTU:      iface.MTU,
+			Up:       iface.Flags&net.FlagUp != 0,
+			Loopback: iface.Flags&net.FlagLoopback != 0,
+			Flags:    iface.Flags.String(),
+		}
+
+		// An interface without addresses is still listed
+		addrs, err := iface.Addrs()
+		if err == nil {
+			for _, addr := range addrs {
+				ipNet, ok := addr.(*net.IPNet)
+				if !ok {
+					continue
+				}
+				if ipNet.IP.To4() != nil {
+					netIface.IPv4 = append(netIface.IPv4, ipNet.String())
+				} else {
+					netIface.IPv6 = append(netIface.IPv6, ipNet.String())
+				}
+			}
+		}
+
+		info.Interfaces = append(info.Interfaces, netIface)
+	}
+
+	return info, nil
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const numaNodePath = "/sys/devices/system/node"
+
+// collectNUMAInfo reads the NUMA nodes from sysfs, sorted by node number.
+// Other platforms, and kernels built without NUMA support, get no nodes.
+func collectNUMAInfo() (*NUMAInfo, error) {
+	dirs, err := filepath.Glob(filepath.Join(numaNodePath, "node[0-9]*"))
+	if err != nil {
+		return nil, err
+	}
+
+	info := &NUMAInfo{}
+	for _, dir := range dirs {
+		id, err := strconv.Atoi(strings.TrimPrefix(filepath.Base(dir), "node"))
+		if err != nil {
+			continue
+		}
+		info.Nodes = append(info.Nodes, NUMANode{
+			Node:        id,
+			CPUs:        readSysfsString(filepath.Join(dir, "cpulist")),
+			MemoryBytes: nodeMemTotal(filepath.Join(dir, "meminfo")),
+		})
+	}
+	sort.Slice(info.Nodes, func(i, j int) bool { return info.Nodes[i].Node < info.Nodes[j].Node })
+	return info, nil
+}
+
+// nodeMemTotal reads the MemTotal line of a node's meminfo, which unlike
+// /proc/meminfo is prefixed with the node: "Node 0 MemTotal:  6127352 kB".
+func nodeMemTotal(path string) uint64 {
+	for _, line := range strings.Split(readSysfsString(path), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) >= 4 && fields[2] == "MemTotal:" {
+			kb, _ := strconv.ParseUint(fields[3], 10, 64)
+			return kb * 1024
+		}
+	}
+	return 0
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/mattn/go-runewidth"
+	"github.com/spf13/cobra"
+)
+
+var onelineWidth int
+
+var onelineCmd = &cobra.Command{
+	Use:   "oneline",
+	Short: "Print a one-line hardware summary",
+	Long: "Prints the CPU, its core and thread counts, largest cache, most notable instruction set extensions and total RAM on a single line, " +
+		"for status bars, prompts and MOTDs. Extensions that don't fit in --width are counted instead of listed.",
+	Args: cobra.NoArgs,
+	Run:  runOneline,
+}
+
+func init() {
+	onelineCmd.Flags().IntVar(&onelineWidth, "width", 120, "Maximum line length in characters")
+	rootCmd.AddCommand(onelineCmd)
+}
+
+// notableFeatures are the extensions the one-line summary lists, most
+// telling first, since the full list is far too long for one line.
+var notableFeatures = []string{
+	"AVX512F", "AMX_TILE", "AVX2", "AVX", "FMA", "AVX_VNNI", "SHA", "VAES", "AES",
+	"SSE4.2", "BMI2", "ADX", "RTM", "SGX", "SEV", "HYPERVISOR",
+}
+
+func runOneline(cmd *cobra.Command, args []string) {
+	if onelineWidth < 1 {
+		fmt.Fprintf(os.Stderr, "Error: --width must be at least 1, got %d\n", onelineWidth)
+		os.Exit(1)
+	}
+	fmt.Println(onelineSummary(collectForExport(), onelineWidth))
+}
+
+// onelineSummary joins the summary fields with " | ", listing as many
+// notable features as fit in width. A line that is still too long, such as
+// one with a very long brand string, is cut short with "...".
+func onelineSummary(hwInfo *HardwareInfo, width int) string {
+	cpu := &hwInfo.CPU
+	head := []string{cpu.Brand, fmt.Sprintf("%dC/%dT", cpu.Cores, cpu.Threads)}
+	if cache := largestCache(cpu.CacheDetails); cache != nil {
+		head = append(head, fmt.Sprintf("%s L%d", formatCacheSize(cache.SizeKB), cache.Level))
+	}
+	var tail []string
+	if hwInfo.RAM.TotalBytes > 0 {
+		tail = append(tail, formatBytes(hwInfo.RAM.TotalBytes)+" RAM")
+	}
+
+	supported := make(map[string]bool, len(cpu.Features))
+	for _, feature := range cpu.Features {
+		supported[feature] = true
+	}
+	var features []string
+	for _, feature := range notableFeatures {
+		if supported[feature] {
+			features = append(features, feature)
+		}
+	}
+
+	line := strings.Join(append(head, tail...), " | ")
+	for n := len(features); n > 0; n-- {
+		list := strings.Join(features[:n], ",")
+		if n < len(features) {
+			list += fmt.Sprintf(" +%d", len(features)-n)
+		}
+		candidate := strings.Join(append(append(head[:len(head):len(head)], list), tail...), " | ")
+		if runewidth.StringWidth(candidate) <= width {
+			line = candidate
+			break
+		}
+	}
+
+	return truncateString(line, width)
+}
+
+// largestCache returns the cache with the highest level, preferring the
+// bigger one when a level has several, or nil when none are known.
+func largestCache(caches []CacheDetail) *CacheDetail {
+	var largest *CacheDetail
+	for i := range caches {
+		c := &caches[i]
+		if largest == nil || c.Level > largest.Level || (c.Level == largest.Level && c.SizeKB > largest.SizeKB) {
+			largest = c
+		}
+	}
+	return largest
+}
+
+// formatCacheSize shows a cache size in KB, or in MB once it is a whole
+// number of them.
+func formatCacheSize(kb uint32) string {
+	if kb >= 1024 && kb%1024 == 0 {
+		return fmt.Sprintf("%dMB", kb/1024)
+	}
+	return fmt.Sprintf("%dKB", kb)
+}
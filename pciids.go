@@ -0,0 +1,120 @@
+package main
+
+import (
+	"bufio"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// pciIDsPaths are where distributions install the pci.ids database.
+var pciIDsPaths = []string{
+	"/usr/share/hwdata/pci.ids",
+	"/usr/share/misc/pci.ids",
+	"/usr/share/pci.ids",
+}
+
+// pciIDs holds the names from a pci.ids database. Subsystem and
+// programming interface names are skipped.
+type pciIDs struct {
+	vendors    map[uint16]string
+	devices    map[uint32]string // Vendor ID << 16 | device ID
+	classes    map[uint8]string
+	subclasses map[uint16]string // Class << 8 | subclass
+}
+
+// loadPCIIDs parses the first pci.ids database found, or returns nil when
+// none is installed.
+func loadPCIIDs() *pciIDs {
+	for _, path := range pciIDsPaths {
+		f, err := os.Open(path)
+		if err != nil {
+			continue
+		}
+		defer f.Close()
+		return parsePCIIDs(bufio.NewScanner(f))
+	}
+	return nil
+}
+
+// parsePCIIDs reads the pci.ids format: vendors at the top level with their
+// devices indented one tab below, then "C" class lines with their
+// subclasses indented the same way.
+func parsePCIIDs(scanner *bufio.Scanner) *pciIDs {
+	ids := &pciIDs{
+		vendors:    make(map[uint16]string),
+		devices:    make(map[uint32]string),
+		classes:    make(map[uint8]string),
+		subclasses: make(map[uint16]string),
+	}
+
+	var vendor uint16
+	var class uint8
+	inClasses := false
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" || line[0] == '#' || strings.HasPrefix(line, "\t\t") {
+			continue
+		}
+
+		indented := line[0] == '\t'
+		id, name, ok := strings.Cut(strings.TrimLeft(line, "\t"), "  ")
+		if !ok {
+			continue
+		}
+		if !indented {
+			inClasses = strings.HasPrefix(id, "C ")
+		}
+
+		switch {
+		case !indented && inClasses:
+			if n, err := strconv.ParseUint(strings.TrimPrefix(id, "C "), 16, 8); err == nil {
+				class = uint8(n)
+				ids.classes[class] = name
+			}
+		case !indented:
+			if n, err := strconv.ParseUint(id, 16, 16); err == nil {
+				vendor = uint16(n)
+				ids.vendors[vendor] = name
+			}
+		case inClasses:
+			if n, err := strconv.ParseUint(id, 16, 8); err == nil {
+				ids.subclasses[uint16(class)<<8|uint16(n)] = name
+			}
+		default:
+			if n, err := strconv.ParseUint(id, 16, 16); err == nil {
+				ids.devices[uint32(vendor)<<16|uint32(n)] = name
+			}
+		}
+	}
+	return ids
+}
+
+// vendor returns the name of a vendor ID, or "" if it isn't listed.
+func (ids *pciIDs) vendor(vendorID uint16) string {
+	if ids == nil {
+		return ""
+	}
+	return ids.vendors[vendorID]
+}
+
+// device returns the name of a vendor's device ID, or "".
+func (ids *pciIDs) device(vendorID, deviceID uint16) string {
+	if ids == nil {
+		return ""
+	}
+	return ids.devices[uint32(vendorID)<<16|uint32(deviceID)]
+}
+
+// class returns the subclass name of a 0xCCSSPP class code, falling back
+// to the class name, or "".
+func (ids *pciIDs) class(code uint32) string {
+	if ids == nil {
+		return ""
+	}
+	class, subclass := uint8(code>>16), uint8(code>>8)
+	if name, ok := ids.subclasses[uint16(class)<<8|uint16(subclass)]; ok {
+		return name
+	}
+	return ids.classes[class]
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// collectPCIInfo lists the PCI devices in sysfs, sorted by address, with
+// names from pci.ids where it is installed. Without it the names are left
+// empty and only the IDs are known.
+func collectPCIInfo() (*PCIInfo, error) {
+	dirs, err := filepath.Glob("/sys/bus/pci/devices/*")
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(dirs)
+
+	info := &PCIInfo{}
+	if len(dirs) == 0 {
+		return info, nil
+	}
+
+	ids := loadPCIIDs()
+	for _, dir := range dirs {
+		class, _ := strconv.ParseUint(strings.TrimPrefix(readSysfsString(filepath.Join(dir, "class")), "0x"), 16, 32)
+		device := PCIDevice{
+			Address:  filepath.Base(dir),
+			VendorID: readSysfsHex(filepath.Join(dir, "vendor")),
+			DeviceID: readSysfsHex(filepath.Join(dir, "device")),
+			Class:    uint32(class),
+		}
+		device.Vendor = ids.vendor(device.VendorID)
+		device.Device = ids.device(device.VendorID, device.DeviceID)
+		device.ClassName = ids.class(device.Class)
+		if link, err := os.Readlink(filepath.Join(dir, "driver")); err == nil {
+			device.Driver = filepath.Base(link)
+		}
+		info.Devices = append(info.Devices, device)
+	}
+	return info, nil
+}
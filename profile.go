@@ -0,0 +1,57 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// collectionProfile records how long each collection step takes. It is nil
+// unless --profile-collection is set, and steps are then free.
+var collectionProfile *stepProfile
+
+type stepProfile struct {
+	mu    sync.Mutex
+	steps []stepTiming // In the order the steps finished
+}
+
+type stepTiming struct {
+	name string
+	took time.Duration
+}
+
+// profileStep starts timing the named step and returns the function that
+// ends it. Collectors run in parallel, so steps may overlap.
+func profileStep(name string) func() {
+	profile := collectionProfile
+	if profile == nil {
+		return func() {}
+	}
+	start := time.Now()
+	return func() {
+		took := time.Since(start)
+		profile.mu.Lock()
+		profile.steps = append(profile.steps, stepTiming{name, took})
+		profile.mu.Unlock()
+	}
+}
+
+// writeCollectionProfile prints the recorded steps, one per line.
+func writeCollectionProfile(w io.Writer) {
+	profile := collectionProfile
+	if profile == nil {
+		return
+	}
+	profile.mu.Lock()
+	defer profile.mu.Unlock()
+
+	width := 0
+	for _, step := range profile.steps {
+		width = max(width, len(step.name))
+	}
+	fmt.Fprintln(w, "Collection profile:")
+	for _, step := range profile.steps {
+		fmt.Fprintf(w, "  %-*s  %v\n", width+1, step.name+":", step.took.Round(time.Microsecond))
+	}
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var prometheusCmd = &cobra.Command{
+	Use:   "prometheus",
+	Short: "Print hardware facts as Prometheus metrics",
+	Long: "Collects hardware information and prints the static facts (CPU, caches, features, memory and disk sizes, GPUs) " +
+		"in the Prometheus text exposition format, for node_exporter's textfile collector. Output is sorted so repeated runs are identical.",
+	Args: cobra.NoArgs,
+	Run:  runPrometheus,
+}
+
+func init() {
+	rootCmd.AddCommand(prometheusCmd)
+}
+
+// promFamily is one metric name with its HELP/TYPE lines and samples.
+type promFamily struct {
+	name    string
+	help    string
+	samples []promSample
+}
+
+type promSample struct {
+	labels [][2]string // Name/value pairs, in the order given
+	value  float64
+}
+
+func (f *promFamily) add(value float64, labels ...string) {
+	sample := promSample{value: value}
+	for i := 0; i+1 < len(labels); i += 2 {
+		sample.labels = append(sample.labels, [2]string{labels[i], labels[i+1]})
+	}
+	f.samples = append(f.samples, sample)
+}
+
+func runPrometheus(cmd *cobra.Command, args []string) {
+	hwInfo := collectForExport()
+
+	err := writeOutput(outputPath, func(w io.Writer) error {
+		return writePrometheus(w, hwInfo)
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing metrics: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// writePrometheus writes hwInfo as gauges in the text exposition format.
+func writePrometheus(w io.Writer, hwInfo *HardwareInfo) error {
+	cpu := hwInfo.CPU
+
+	cpuInfo := &promFamily{name: "earhw_cpu_info", help: "CPU identification; always 1."}
+	cpuInfo.add(1, "vendor", cpu.Vendor, "brand", cpu.Brand, "family", strconv.FormatUint(uint64(cpu.Family), 10),
+		"model", strconv.FormatUint(uint64(cpu.ModelNumber), 10), "stepping", strconv.FormatUint(uint64(cpu.Stepping), 10))
+
+	cores := &promFamily{name: "earhw_cpu_cores", help: "Number of physical CPU cores."}
+	cores.add(float64(cpu.Cores))
+
+	threads := &promFamily{name: "earhw_cpu_threads", help: "Number of logical CPU threads."}
+	threads.add(float64(cpu.Threads))
+
+	baseMHz := &promFamily{name: "earhw_cpu_base_mhz", help: "Nominal CPU base frequency in MHz; 0 if unknown."}
+	baseMHz.add(float64(cpu.BaseMHz))
+
+	cacheSize := &promFamily{name: "earhw_cpu_cache_size_kb", help: "CPU cache size in KB by level and type."}
+	for _, cache := range cpu.CacheDetails {
+		cacheSize.add(float64(cache.SizeKB), "level", strconv.FormatUint(uint64(cache.Level), 10), "type", cache.Type)
+	}
+
+	feature := &promFamily{name: "earhw_cpu_feature", help: "Supported CPU feature flags; 1 for each supported feature."}
+	seen := make(map[string]bool)
+	for _, name := range cpu.Features {
+		name = strings.ToLower(name)
+		if !seen[name] {
+			seen[name] = true
+			feature.add(1, "name", name)
+		}
+	}
+
+	memTotal := &promFamily{name: "earhw_memory_total_bytes", help: "Total physical memory in bytes."}
+	memTotal.add(float64(hwInfo.RAM.TotalBytes))
+
+	swapTotal := &promFamily{name: "earhw_swap_total_bytes", help: "Total swap space in bytes."}
+	swapTotal.add(float64(hwInfo.RAM.SwapTotalBytes))
+
+	diskSize := &promFamily{name: "earhw_disk_size_bytes", help: "Size of each mounted filesystem in bytes."}
+	for _, disk := range hwInfo.Disk.Devices {
+		diskSize.add(float64(disk.TotalBytes), "device", disk.Device, "mountpoint", disk.MountPoint, "fstype", disk.FSType)
+	}
+
+	gpuInfo := &promFamily{name: "earhw_gpu_info", help: "Graphics device identification; always 1."}
+	for _, gpu := range hwInfo.GPU.Devices {
+		gpuInfo.add(1, "card", gpu.Card, "vendor", gpu.Vendor, "model", gpu.Model, "driver", gpu.Driver, "pci_address", gpu.PCIAddress)
+	}
+
+	families := []*promFamily{cpuInfo, cores, threads, baseMHz, cacheSize, feature, memTotal, swapTotal, diskSize, gpuInfo}
+	sort.Slice(families, func(i, j int) bool { return families[i].name < families[j].name })
+
+	var b strings.Builder
+	for _, family := range families {
+		if len(family.samples) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "# HELP %s %s\n", family.name, family.help)
+		fmt.Fprintf(&b, "# TYPE %s gauge\n", family.name)
+
+		// A series may appear only once; a filesystem mounted over itself
+		// would otherwise repeat its labels
+		values := make(map[string]float64)
+		series := make([]string, 0, len(family.samples))
+		for _, sample := range family.samples {
+			key := family.name + formatPromLabels(sample.labels)
+			if _, ok := values[key]; !ok {
+				values[key] = sample.value
+				series = append(series, key)
+			}
+		}
+		sort.Strings(series)
+		for _, key := range series {
+			fmt.Fprintf(&b, "%s %s\n", key, strconv.FormatFloat(values[key], 'f', -1, 64))
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// formatPromLabels renders labels as {name="value",...}, sorted by name,
+// with values escaped per the exposition format.
+func formatPromLabels(labels [][2]string) string {
+	if len(labels) == 0 {
+		return ""
+	}
+	sorted := append([][2]string(nil), labels...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i][0] < sorted[j][0] })
+
+	parts := make([]string, len(sorted))
+	for i, label := range sorted {
+		parts[i] = label[0] + `="` + strings.TrimSpace(promLabelReplacer.Replace(label[1])) + `"`
+	}
+	return "{" + strings.Join(parts, ",") + "}"
+}
+
+// promLabelReplacer escapes label values; NULs (padding in the CPU brand
+// string) are dropped.
+var promLabelReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\x00", "")
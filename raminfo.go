@@ -0,0 +1,65 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+func collectRAMInfo() (*RAMInfo, error) {
+	// /proc/meminfo reports every value in kB
+	file, err := os.Open("/proc/meminfo")
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	values := make(map[string]uint64)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		key, rest, ok := strings.Cut(scanner.Text(), ":")
+		if !ok {
+			continue
+		}
+		fields := strings.Fields(rest)
+		if len(fields) == 0 {
+			continue
+		}
+		kb, err := strconv.ParseUint(fields[0], 10, 64)
+		if err != nil {
+			continue
+		}
+		values[key] = kb * 1024
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	total, ok := values["MemTotal"]
+	if !ok {
+		return nil, fmt.Errorf("MemTotal missing from /proc/meminfo")
+	}
+
+	// Older kernels lack MemAvailable, so approximate it
+	available, ok := values["MemAvailable"]
+	if !ok {
+		available = values["MemFree"] + values["Buffers"] + values["Cached"]
+	}
+	used := uint64(0)
+	if total > available {
+		used = total - available
+	}
+
+	return &RAMInfo{
+		TotalBytes:     total,
+		AvailableBytes: available,
+		UsedBytes:      used,
+		FreeBytes:      values["MemFree"],
+		BuffersBytes:   values["Buffers"],
+		CachedBytes:    values["Cached"],
+		SwapTotalBytes: values["SwapTotal"],
+		SwapFreeBytes:  values["SwapFree"],
+	}, nil
+}
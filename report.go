@@ -0,0 +1,821 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// reportSection is a titled block of label/value lines. The TUI pages and
+// the text exports both build on these so the wording only lives here.
+type reportSection struct {
+	Title string
+	Lines []reportLine
+}
+
+// reportLine is one line of a section. Indent nests detail lines under the
+// line above them; Heading marks sub-headings such as the TLB levels. Wrap
+// lets the TUI wrap a long value under itself instead of cutting it off.
+type reportLine struct {
+	Text    string
+	Indent  int
+	Heading bool
+	Wrap    bool
+}
+
+func (s *reportSection) add(format string, args ...any) {
+	s.Lines = append(s.Lines, reportLine{Text: fmt.Sprintf(format, args...)})
+}
+
+func (s *reportSection) addIndented(indent int, format string, args ...any) {
+	s.Lines = append(s.Lines, reportLine{Text: fmt.Sprintf(format, args...), Indent: indent})
+}
+
+func (s *reportSection) addWrapped(format string, args ...any) {
+	s.Lines = append(s.Lines, reportLine{Text: fmt.Sprintf(format, args...), Wrap: true})
+}
+
+func (s *reportSection) addHeading(text string) {
+	s.Lines = append(s.Lines, reportLine{Text: text, Heading: true})
+}
+
+// restrictedCPUIDNote explains placeholder vendor and brand strings.
+const restrictedCPUIDNote = "(not reported by CPUID; likely a restricted virtual machine or emulator)"
+
+// cpuSections returns the label/value sections of the CPU page, in display
+// order. Sections without data are left out.
+func cpuSections(cpu *CPUInfo, glyphs *glyphSet) []reportSection {
+	sections := cpuDetailSections(cpu)
+	if len(cpu.CacheDetails) > 0 {
+		sections = append(sections, cacheTreeSection(cpu.CacheDetails, glyphs))
+	}
+	if tlb := tlbSection(&cpu.TLBInfo); len(tlb.Lines) > 0 {
+		sections = append(sections, tlb)
+	}
+	return sections
+}
+
+// cpuDetailSections returns the CPU page's sections up to the caches: the
+// identification, clock and model data that has no tabular form.
+func cpuDetailSections(cpu *CPUInfo) []reportSection {
+	sections := []reportSection{}
+
+	basic := reportSection{Title: "Basic Information"}
+	basic.add("Vendor:        %s", cpu.Vendor)
+	basic.addWrapped("Brand:         %s", cpu.Brand)
+	if cpu.RestrictedCPUID {
+		basic.addIndented(1, restrictedCPUIDNote)
+	}
+	basic.add("Model:         %s", cpu.Model)
+	basic.add("Family:        %d", cpu.Family)
+	basic.add("Model Number:  %d", cpu.ModelNumber)
+	basic.add("Stepping:      %d", cpu.Stepping)
+	basic.add("Microcode:     %s", valueOrUnknown(cpu.Microcode))
+	basic.add("Cores:         %d", cpu.Cores)
+	basic.add("Threads:       %d", cpu.Threads)
+	if cpu.ThreadsNote != "" {
+		basic.addIndented(1, "(%s)", cpu.ThreadsNote)
+	}
+	basic.add("Max Func:      %d", cpu.MaxFunc)
+	basic.add("Max Ext Func:  %d", cpu.MaxExtFunc)
+	basic.add("Phys Addr Bits: %d%s", cpu.PhysicalAddrBits, addressSpace(cpu.PhysicalAddrBits, "addressable"))
+	basic.add("Linear Addr Bits: %d%s", cpu.LinearAddrBits, addressSpace(cpu.LinearAddrBits, "virtual"))
+	sections = append(sections, basic)
+
+	arch := reportSection{Title: "Architecture"}
+	arch.add("Binary Arch:   %s", cpu.GoArch)
+	arch.add("Pointer Size:  %d bits", cpu.PointerBits)
+	arch.add("Byte Order:    %s", cpu.ByteOrder)
+	sections = append(sections, arch)
+
+	// Clock Speeds (omitted when the platform reports none)
+	if cpu.BaseMHz > 0 || len(cpu.CurrentMHz) > 0 {
+		clock := reportSection{Title: "Clock Speeds"}
+		if cpu.BaseMHz > 0 {
+			clock.add("Base:          %d MHz", cpu.BaseMHz)
+		}
+		if len(cpu.CurrentMHz) > 0 {
+			minMHz, maxMHz, avgMHz := mhzStats(cpu.CurrentMHz)
+			clock.add("Current:       min %d / max %d / avg %d MHz (%d CPUs)", minMHz, maxMHz, avgMHz, len(cpu.CurrentMHz))
+		}
+		sections = append(sections, clock)
+	}
+
+	processor := reportSection{Title: "Processor Details"}
+	processor.add("Max Logical Processors: %d", cpu.ProcessorInfo.MaxLogicalProcessors)
+	processor.add("Initial APIC ID: %d", cpu.ProcessorInfo.InitialAPICID)
+	processor.add("Threads Per Core: %d", cpu.ProcessorInfo.ThreadPerCore)
+	sections = append(sections, processor)
+
+	if len(cpu.NUMA.Nodes) > 0 {
+		sections = append(sections, numaSection(&cpu.NUMA))
+	}
+
+	model := reportSection{Title: "Model Data"}
+	model.add("Stepping ID: %d | Model ID: %d | Family ID: %d",
+		cpu.ModelData.SteppingID, cpu.ModelData.ModelID, cpu.ModelData.FamilyID)
+	model.add("Extended Model: %d | Extended Family: %d",
+		cpu.ModelData.ExtendedModel, cpu.ModelData.ExtendedFamily)
+	model.add("Processor Type: %d", cpu.ModelData.ProcessorType)
+	sections = append(sections, model)
+
+	if cpu.HybridInfo.IsHybrid {
+		hybrid := reportSection{Title: "Hybrid CPU Information"}
+		hybrid.add("Core Type: %s", cpu.HybridInfo.CoreType)
+		sections = append(sections, hybrid)
+	}
+
+	return sections
+}
+
+// numaSection lists each NUMA node's CPUs and memory.
+func numaSection(numa *NUMAInfo) reportSection {
+	section := reportSection{Title: "NUMA"}
+	if len(numa.Nodes) == 1 {
+		section.add("1 node (UMA)")
+	} else {
+		section.add("%d nodes", len(numa.Nodes))
+	}
+	for _, node := range numa.Nodes {
+		section.add("Node %d: CPUs %s, %s", node.Node, node.CPUs, formatBytes(node.MemoryBytes))
+	}
+	return section
+}
+
+// addressSpace returns " (<size> <what>)" for the memory an address of the
+// given width can reach, or "" when the width is unknown.
+func addressSpace(bits uint32, what string) string {
+	if bits == 0 {
+		return ""
+	}
+	size := uint64(math.MaxUint64)
+	if bits < 64 {
+		size = 1 << bits
+	}
+	return fmt.Sprintf(" (%s %s)", formatWholeBytes(size), what)
+}
+
+// formatWholeBytes is formatBytes without the ".00" on whole amounts, such
+// as the powers of two of address spaces and caches.
+func formatWholeBytes(bytes uint64) string {
+	return strings.Replace(formatBytes(bytes), ".00 ", " ", 1)
+}
+
+// tlbLevel is one TLB with its entries per page size.
+type tlbLevel struct {
+	name    string
+	entries []TLBEntry
+}
+
+// tlbLevels lists the TLBs in display order, including empty ones.
+func tlbLevels(tlb *TLBInfo) []tlbLevel {
+	return []tlbLevel{
+		{"L1 Data TLB", tlb.L1Data},
+		{"L1 Instruction TLB", tlb.L1Inst},
+		{"L2 Unified TLB", tlb.L2Unified},
+		{"L3 Unified TLB", tlb.L3Unified},
+	}
+}
+
+// reportTable is tabular data for the exports that can lay it out as a
+// table rather than as aligned text lines.
+type reportTable struct {
+	Title  string
+	Header []string
+	Rows   [][]string
+}
+
+// cpuTables returns the cache, TLB and feature tables, leaving out any that
+// would be empty.
+func cpuTables(cpu *CPUInfo) []reportTable {
+	tables := []reportTable{}
+
+	if len(cpu.CacheDetails) > 0 {
+		cache := reportTable{
+			Title:  "Cache",
+			Header: []string{"Level", "Type", "Size", "Ways", "Line", "Sets", "Cores Sharing", "Write Policy"},
+		}
+		for _, c := range cpu.CacheDetails {
+			ways := fmt.Sprintf("%d", c.Ways)
+			if c.FullyAssociative {
+				ways = "full"
+			}
+			cache.Rows = append(cache.Rows, []string{
+				fmt.Sprintf("L%d", c.Level), c.Type, fmt.Sprintf("%d KB", c.SizeKB), ways,
+				fmt.Sprintf("%d B", c.LineSizeBytes), fmt.Sprintf("%d", c.TotalSets),
+				fmt.Sprintf("%d", c.MaxCoresSharing), c.WritePolicy,
+			})
+		}
+		tables = append(tables, cache)
+	}
+
+	tlbs := reportTable{Title: "TLB", Header: []string{"TLB", "Page Size", "Entries", "Associativity"}}
+	for _, level := range tlbLevels(&cpu.TLBInfo) {
+		for _, e := range level.entries {
+			tlbs.Rows = append(tlbs.Rows, []string{level.name, e.PageSize, fmt.Sprintf("%d", e.Entries), e.Associativity})
+		}
+	}
+	if len(tlbs.Rows) > 0 {
+		tables = append(tables, tlbs)
+	}
+
+	if len(cpu.FeatureCategories) > 0 {
+		features := reportTable{
+			Title:  fmt.Sprintf("Features (%d total)", len(cpu.Features)),
+			Header: []string{"Category", "Count", "Features"},
+		}
+		for _, category := range sortedCategoryNames(cpu.FeatureCategories) {
+			names := make([]string, 0, len(cpu.FeatureCategories[category]))
+			for _, feat := range cpu.FeatureCategories[category] {
+				names = append(names, feat.Name)
+			}
+			features.Rows = append(features.Rows, []string{category, fmt.Sprintf("%d", len(names)), strings.Join(names, ", ")})
+		}
+		tables = append(tables, features)
+	}
+
+	return tables
+}
+
+// tlbSection lists each TLB level's entries per page size. It has no lines
+// when CPUID reported no TLBs.
+func tlbSection(tlb *TLBInfo) reportSection {
+	section := reportSection{Title: "TLB (Translation Lookaside Buffer)"}
+	for _, level := range tlbLevels(tlb) {
+		if len(level.entries) == 0 {
+			continue
+		}
+		section.addHeading(level.name + ":")
+		for _, e := range level.entries {
+			section.addIndented(1, "%s: %d entries, %s associativity", e.PageSize, e.Entries, e.Associativity)
+		}
+	}
+	return section
+}
+
+// cacheTreeSection lays the caches out as a tree: one heading per level,
+// a branch per cache at that level, and its geometry and sharing below.
+// Each cache gets a size bar scaled to the largest one.
+func cacheTreeSection(details []CacheDetail, glyphs *glyphSet) reportSection {
+	const barWidth = 20
+
+	caches := append([]CacheDetail(nil), details...)
+	largestKB, typeWidth := 0, 0
+	for _, c := range caches {
+		largestKB = max(largestKB, int(c.SizeKB))
+		typeWidth = max(typeWidth, len(c.Type)+1)
+	}
+	sort.SliceStable(caches, func(i, j int) bool {
+		if caches[i].Level != caches[j].Level {
+			return caches[i].Level < caches[j].Level
+		}
+		return caches[i].Type < caches[j].Type
+	})
+
+	section := reportSection{Title: "Detailed Cache Information"}
+	for i, c := range caches {
+		if i == 0 || c.Level != caches[i-1].Level {
+			section.addHeading(fmt.Sprintf("L%d", c.Level))
+		}
+		last := i == len(caches)-1 || caches[i+1].Level != c.Level
+		branch, stem := glyphs.treeBranch, glyphs.treeStem
+		if last {
+			branch, stem = glyphs.treeLast, "  "
+		}
+
+		associativity := fmt.Sprintf("%d-way", c.Ways)
+		if c.FullyAssociative {
+			associativity = "fully associative"
+		}
+		section.addIndented(1, "%s %-*s %s %d KB, %d bytes/line, %d sets", branch, typeWidth, c.Type+":",
+			bar(int(c.SizeKB), largestKB, barWidth, glyphs), c.SizeKB, c.LineSizeBytes, c.TotalSets)
+		section.addIndented(1, "%s   Associativity: %s | Max Cores Sharing: %d | Max Processor IDs: %d",
+			stem, associativity, c.MaxCoresSharing, c.MaxProcessorIDs)
+		section.addIndented(1, "%s   Write Policy: %s | Self-Init: %v", stem, c.WritePolicy, c.SelfInitializing)
+	}
+	return section
+}
+
+// memoryModulesSection lists each memory slot with its module, or "Empty".
+func memoryModulesSection(modules []MemoryModule) reportSection {
+	section := reportSection{Title: "Modules"}
+	for _, m := range modules {
+		slot := valueOrUnknown(m.Slot)
+		if m.Bank != "" {
+			slot += " (" + m.Bank + ")"
+		}
+		section.addHeading(slot)
+		if m.SizeBytes == 0 {
+			section.addIndented(1, "Empty")
+			continue
+		}
+		section.addIndented(1, "%s %s @ %d MT/s", formatBytes(m.SizeBytes), valueOrUnknown(m.Type), m.SpeedMTs)
+		section.addIndented(1, "Manufacturer: %s | Part Number: %s", valueOrUnknown(m.Manufacturer), valueOrUnknown(m.PartNumber))
+	}
+	return section
+}
+
+// valueOrUnknown substitutes "unknown" for values the platform didn't report.
+func valueOrUnknown(s string) string {
+	if s == "" {
+		return "unknown"
+	}
+	return s
+}
+
+// sortedCategoryNames returns the keys of a feature category map, sorted.
+func sortedCategoryNames(categories map[string][]FeatureDetail) []string {
+	names := make([]string, 0, len(categories))
+	for name := range categories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// cpuFeatureSections returns the CPU page's feature lists as sections,
+// with the names wrapped to fit textWidth columns.
+func cpuFeatureSections(cpu *CPUInfo, textWidth int) []reportSection {
+	sections := []reportSection{}
+
+	if len(cpu.FeatureCategories) > 0 {
+		categories := reportSection{Title: "Supported Features by Category"}
+		for _, category := range sortedCategoryNames(cpu.FeatureCategories) {
+			features := cpu.FeatureCategories[category]
+			names := make([]string, 0, len(features))
+			for _, feat := range features {
+				names = append(names, feat.Name)
+			}
+			categories.add("%s (%d features)", category, len(features))
+			for _, line := range wrapText(strings.Join(names, " "), textWidth-4) {
+				categories.addIndented(1, "%s", line)
+			}
+		}
+		sections = append(sections, categories)
+	}
+
+	if len(cpu.FeatureDetails) > 0 {
+		// --descriptions: one feature per line with its vendor and description
+		all := reportSection{Title: fmt.Sprintf("All Supported Features (%d total)", len(cpu.FeatureDetails))}
+		for _, feat := range cpu.FeatureDetails {
+			all.add("%s [%s]", feat.Name, valueOrUnknown(feat.Vendor))
+			for _, line := range wrapText(feat.Description, textWidth-4) {
+				if line != "" {
+					all.addIndented(1, "%s", line)
+				}
+			}
+		}
+		sections = append(sections, all)
+	} else if len(cpu.Features) > 0 {
+		all := reportSection{Title: fmt.Sprintf("All Supported Features (%d total)", len(cpu.Features))}
+		rest, avx512 := splitAVX512(cpu.Features)
+		if len(avx512) > 0 {
+			all.addHeading("AVX-512:")
+			for _, line := range wrapText(strings.Join(avx512, " "), textWidth-4) {
+				all.addIndented(1, "%s", line)
+			}
+		}
+		for _, line := range wrapText(strings.Join(rest, " "), textWidth-2) {
+			all.add("%s", line)
+		}
+		sections = append(sections, all)
+	}
+
+	return sections
+}
+
+// splitAVX512 separates the AVX-512 extensions from features so they can be
+// listed together by suffix ("F", "VNNI", ...) instead of as a wall of
+// AVX512 names. The rest keep their order.
+func splitAVX512(features []string) (rest, suffixes []string) {
+	for _, name := range features {
+		suffix, ok := strings.CutPrefix(strings.ToUpper(name), "AVX512")
+		if !ok || suffix == "" {
+			rest = append(rest, name)
+			continue
+		}
+		suffixes = append(suffixes, strings.TrimLeft(suffix, "_-"))
+	}
+	return rest, suffixes
+}
+
+// summarySections returns the Summary page: whether it runs in a VM, uptime
+// and load where the platform reports them, then CPU, feature and cache
+// overviews.
+func summarySections(hwInfo *HardwareInfo) []reportSection {
+	sections := []reportSection{}
+
+	// Shown first: CPU features and counts read differently in a VM
+	platform := reportSection{Title: "Platform"}
+	if hwInfo.CPU.Hypervisor != "" {
+		platform.add("Running under: %s", hwInfo.CPU.Hypervisor)
+	} else {
+		platform.add("Bare metal")
+	}
+	sections = append(sections, platform)
+
+	if stats := hwInfo.Stats; stats.UptimeSeconds > 0 {
+		uptime := reportSection{Title: "Uptime / Load"}
+		uptime.add("Uptime:     %s", formatUptime(stats.UptimeSeconds))
+		uptime.add("Load:       %.2f %.2f %.2f (1, 5, 15 min)", stats.Load1, stats.Load5, stats.Load15)
+		sections = append(sections, uptime)
+	}
+
+	cpu := reportSection{Title: "CPU"}
+	cpu.add("Vendor:     %s", hwInfo.CPU.Vendor)
+	cpu.add("Brand:      %s", hwInfo.CPU.Brand)
+	if hwInfo.CPU.RestrictedCPUID {
+		cpu.addIndented(1, restrictedCPUIDNote)
+	}
+	cpu.add("Cores:      %d", hwInfo.CPU.Cores)
+	cpu.add("Threads:    %d", hwInfo.CPU.Threads)
+	if hwInfo.CPU.ThreadsNote != "" {
+		cpu.addIndented(1, "(%s)", hwInfo.CPU.ThreadsNote)
+	}
+	if level := isaLevel(&hwInfo.CPU); level != "" {
+		cpu.add("ISA Level:  %s", level)
+	}
+	sections = append(sections, cpu)
+
+	features := reportSection{Title: "Features"}
+	features.add("Total Features: %d", len(hwInfo.CPU.Features))
+	features.add("Categories:     %d", len(hwInfo.CPU.FeatureCategories))
+	if top := largestCategories(hwInfo.CPU.FeatureCategories, summaryTopCategories); len(top) > 0 {
+		features.add("Top Categories:")
+		for _, category := range top {
+			features.addIndented(1, "%s: %d", category, len(hwInfo.CPU.FeatureCategories[category]))
+		}
+	}
+	sections = append(sections, features)
+
+	if len(hwInfo.CPU.CacheDetails) > 0 {
+		cache := reportSection{Title: "Cache"}
+		for _, c := range hwInfo.CPU.CacheDetails {
+			cache.add("L%d %s: %d KB", c.Level, c.Type, c.SizeKB)
+		}
+		for _, total := range cacheTotals(hwInfo.CPU.CacheDetails, hwInfo.CPU.Threads) {
+			cache.add("Total L%d: %s", total.level, formatWholeBytes(total.kb*1024))
+		}
+		sections = append(sections, cache)
+	}
+
+	return sections
+}
+
+// cacheTotal is the combined size of every instance of a cache level.
+type cacheTotal struct {
+	level uint32
+	kb    uint64
+}
+
+// cacheTotals sums each cache level across the package. A cache shared by
+// MaxCoresSharing logical CPUs has one instance per that many of the
+// threads, so an L3 shared by all of them is counted once and a per-core
+// L2 once per core.
+func cacheTotals(caches []CacheDetail, threads uint32) []cacheTotal {
+	totals := []cacheTotal{}
+	for _, c := range caches {
+		instances := uint64(1)
+		if sharing := uint64(c.MaxCoresSharing); sharing > 0 && threads > 0 {
+			instances = (uint64(threads) + sharing - 1) / sharing
+		}
+		kb := uint64(c.SizeKB) * instances
+
+		idx := slices.IndexFunc(totals, func(t cacheTotal) bool { return t.level == c.Level })
+		if idx < 0 {
+			totals = append(totals, cacheTotal{level: c.Level})
+			idx = len(totals) - 1
+		}
+		totals[idx].kb += kb
+	}
+	sort.Slice(totals, func(i, j int) bool { return totals[i].level < totals[j].level })
+	return totals
+}
+
+// summaryTopCategories is how many of the largest feature categories the
+// Summary page lists.
+const summaryTopCategories = 5
+
+// largestCategories returns up to n category names with the most features,
+// largest first and ties in name order.
+func largestCategories(categories map[string][]FeatureDetail, n int) []string {
+	names := sortedCategoryNames(categories)
+	sort.SliceStable(names, func(i, j int) bool {
+		return len(categories[names[i]]) > len(categories[names[j]])
+	})
+	return names[:min(n, len(names))]
+}
+
+// ramSections returns the RAM page: memory and swap usage, then the
+// modules when DMI was readable.
+func ramSections(ram *RAMInfo) []reportSection {
+	memory := reportSection{Title: "Memory"}
+	if ram.TotalBytes == 0 {
+		memory.add("Memory information unavailable")
+		return []reportSection{memory}
+	}
+	memory.add("Total:      %s", formatBytes(ram.TotalBytes))
+	memory.add("Used:       %s (%.1f%%)", formatBytes(ram.UsedBytes), float64(ram.UsedBytes)*100/float64(ram.TotalBytes))
+	memory.add("Available:  %s", formatBytes(ram.AvailableBytes))
+	memory.add("Free:       %s", formatBytes(ram.FreeBytes))
+	memory.add("Buffers:    %s", formatBytes(ram.BuffersBytes))
+	memory.add("Cached:     %s", formatBytes(ram.CachedBytes))
+
+	swap := reportSection{Title: "Swap"}
+	if ram.SwapTotalBytes == 0 {
+		swap.add("No swap configured")
+	} else {
+		swapUsed := ram.SwapTotalBytes - ram.SwapFreeBytes
+		swap.add("Total:      %s", formatBytes(ram.SwapTotalBytes))
+		swap.add("Used:       %s (%.1f%%)", formatBytes(swapUsed), float64(swapUsed)*100/float64(ram.SwapTotalBytes))
+		swap.add("Free:       %s", formatBytes(ram.SwapFreeBytes))
+	}
+
+	sections := []reportSection{memory, swap}
+	if len(ram.Modules) > 0 {
+		sections = append(sections, memoryModulesSection(ram.Modules))
+	}
+	return sections
+}
+
+// diskSection lists each mounted filesystem with its usage and health. The
+// Disk page draws the same lines itself to color the health result.
+func diskSection(disk *DiskInfo) reportSection {
+	section := reportSection{Title: "Mounted Filesystems"}
+	if len(disk.Devices) == 0 {
+		section.add("Disk information unavailable")
+	}
+	for _, d := range disk.Devices {
+		section.addHeading(fmt.Sprintf("%s (%s) on %s", d.MountPoint, d.FSType, d.Device))
+		section.addIndented(1, "Total: %s | Used: %s (%s) | Free: %s",
+			formatBytes(d.TotalBytes), formatBytes(d.UsedBytes), formatPercent(d.UsedBytes, d.TotalBytes), formatBytes(d.FreeBytes))
+		if d.Health != "" {
+			section.addIndented(1, "Health: %s", d.Health)
+		}
+	}
+	return section
+}
+
+// gpuSection lists each graphics device with its PCI identity and VRAM.
+func gpuSection(gpuInfo *GPUInfo) reportSection {
+	section := reportSection{Title: "Graphics Devices"}
+	if len(gpuInfo.Devices) == 0 {
+		section.add("No GPU detected")
+	}
+	for _, gpu := range gpuInfo.Devices {
+		model := gpu.Model
+		if model == "" {
+			model = fmt.Sprintf("Device %04x", gpu.DeviceID)
+		}
+		section.addHeading(fmt.Sprintf("%s: %s %s", gpu.Card, gpu.Vendor, model))
+		section.addIndented(1, "PCI ID: %04x:%04x | Address: %s | Driver: %s",
+			gpu.VendorID, gpu.DeviceID, valueOrUnknown(gpu.PCIAddress), valueOrUnknown(gpu.Driver))
+		if gpu.VRAMBytes > 0 {
+			section.addIndented(1, "VRAM: %s", formatBytes(gpu.VRAMBytes))
+		} else {
+			section.addIndented(1, "VRAM: unknown")
+		}
+	}
+	return section
+}
+
+// networkSection lists each interface with its state and addresses. The
+// Network page draws the same lines itself to dim the loopback interface.
+func networkSection(network *NetworkInfo) reportSection {
+	section := reportSection{Title: "Network Interfaces"}
+	if len(network.Interfaces) == 0 {
+		section.add("No network interfaces found")
+	}
+	for _, iface := range network.Interfaces {
+		section.addHeading(fmt.Sprintf("%s (%s)", iface.Name, interfaceState(iface)))
+		for _, line := range interfaceDetails(iface) {
+			section.addIndented(1, "%s", line)
+		}
+	}
+	return section
+}
+
+// interfaceState is "up" or "down".
+func interfaceState(iface NetInterface) string {
+	if iface.Up {
+		return "up"
+	}
+	return "down"
+}
+
+// interfaceDetails returns the lines shown under an interface's name.
+func interfaceDetails(iface NetInterface) []string {
+	mac := iface.MAC
+	if mac == "" {
+		mac = "none"
+	}
+	lines := []string{
+		fmt.Sprintf("MAC: %s | MTU: %d", mac, iface.MTU),
+		fmt.Sprintf("Flags: %s", iface.Flags),
+	}
+	for _, addr := range iface.IPv4 {
+		lines = append(lines, "IPv4: "+addr)
+	}
+	for _, addr := range iface.IPv6 {
+		lines = append(lines, "IPv6: "+addr)
+	}
+	return lines
+}
+
+// cpuidSection lists the raw registers of each CPUID leaf and subleaf as a
+// hex table.
+func cpuidSection(cpu *CPUInfo) reportSection {
+	section := reportSection{Title: "Raw CPUID Leaves"}
+	if len(cpu.RawCPUID) == 0 {
+		section.add("Raw CPUID data unavailable")
+		return section
+	}
+	section.addHeading("Leaf      Sub  EAX      EBX      ECX      EDX")
+	for _, l := range cpu.RawCPUID {
+		section.add("%08x  %3x  %08x %08x %08x %08x", l.Leaf, l.Subleaf, l.EAX, l.EBX, l.ECX, l.EDX)
+	}
+	return section
+}
+
+// pciSection lists each PCI device with its class, vendor and device,
+// showing the raw IDs where pci.ids has no name for them.
+func pciSection(pci *PCIInfo) reportSection {
+	section := reportSection{Title: "PCI Devices"}
+	if len(pci.Devices) == 0 {
+		section.add("PCI information unavailable")
+	}
+	for _, d := range pci.Devices {
+		class := d.ClassName
+		if class == "" {
+			class = fmt.Sprintf("Class %04x", d.Class>>8)
+		}
+		section.addHeading(fmt.Sprintf("%s %s", d.Address, class))
+		section.addIndented(1, "Vendor: %s [%04x]", valueOrUnknown(d.Vendor), d.VendorID)
+		section.addIndented(1, "Device: %s [%04x]", valueOrUnknown(d.Device), d.DeviceID)
+		if d.Driver != "" {
+			section.addIndented(1, "Driver: %s", d.Driver)
+		}
+	}
+	return section
+}
+
+// usbSection lists the USB devices as a tree under their root hubs, with
+// hubs drawn as sub-headings and tagged.
+func usbSection(usb *USBInfo) reportSection {
+	section := reportSection{Title: "USB Devices"}
+	if len(usb.Devices) == 0 {
+		section.add("No USB devices found")
+	}
+	for _, d := range usb.Devices {
+		name := strings.TrimSpace(d.Manufacturer + " " + d.Product)
+		if name == "" {
+			name = "Unknown device"
+		}
+		text := fmt.Sprintf("%s: %s [%04x:%04x]", d.Name, name, d.VendorID, d.ProductID)
+		if d.Speed != "" {
+			text += fmt.Sprintf(" %s Mbps", d.Speed)
+		}
+		switch {
+		case d.RootHub:
+			text += " (root hub)"
+		case d.Hub:
+			text += " (hub)"
+		}
+		section.Lines = append(section.Lines, reportLine{Text: text, Indent: d.Depth, Heading: d.Hub || d.RootHub})
+	}
+	return section
+}
+
+// sensorSection lists each hwmon chip's readings with their units.
+func sensorSection(sensors *SensorInfo, glyphs *glyphSet) reportSection {
+	section := reportSection{Title: "Sensors"}
+	if len(sensors.Chips) == 0 {
+		section.add("No hardware sensors found")
+		section.add("hwmon (Linux) reports none; the chip's driver may not be loaded")
+	}
+	for _, chip := range sensors.Chips {
+		section.addHeading(fmt.Sprintf("%s (%s)", valueOrUnknown(chip.Name), chip.Device))
+		for _, s := range chip.Sensors {
+			switch s.Kind {
+			case "temperature":
+				section.addIndented(1, "%s: %.1f%sC", s.Label, s.Value, glyphs.degree)
+			case "fan":
+				section.addIndented(1, "%s: %.0f RPM", s.Label, s.Value)
+			case "voltage":
+				section.addIndented(1, "%s: %.3f V", s.Label, s.Value)
+			}
+		}
+	}
+	return section
+}
+
+// systemSections returns the System page's DMI identity and BIOS sections.
+func systemSections(sys *SystemInfo) []reportSection {
+	system := reportSection{Title: "System"}
+	if sys.SystemVendor == "" && sys.ProductName == "" && sys.BoardVendor == "" && sys.BoardName == "" && sys.ChassisType == "" {
+		system.add("System information unavailable")
+	} else {
+		system.add("Manufacturer: %s", valueOrUnknown(sys.SystemVendor))
+		system.add("Product: %s", valueOrUnknown(sys.ProductName))
+		system.add("Motherboard: %s %s", valueOrUnknown(sys.BoardVendor), sys.BoardName)
+		if sys.BoardVersion != "" {
+			system.add("Board Version: %s", sys.BoardVersion)
+		}
+		system.add("Chassis: %s", valueOrUnknown(sys.ChassisType))
+	}
+
+	bios := reportSection{Title: "BIOS"}
+	if sys.BIOSVendor == "" && sys.BIOSVersion == "" && sys.BIOSDate == "" {
+		bios.add("BIOS information unavailable")
+	} else {
+		bios.add("Vendor: %s", valueOrUnknown(sys.BIOSVendor))
+		bios.add("Version: %s", valueOrUnknown(sys.BIOSVersion))
+		bios.add("Release Date: %s", valueOrUnknown(sys.BIOSDate))
+	}
+
+	return []reportSection{system, bios}
+}
+
+// batterySection lists each battery with a charge bar, its capacity
+// against the design capacity, and its cycle count where reported.
+func batterySection(battery *BatteryInfo, glyphs *glyphSet) reportSection {
+	const barWidth = 20
+
+	section := reportSection{Title: "Batteries"}
+	for _, b := range battery.Batteries {
+		section.addHeading(strings.TrimSpace(fmt.Sprintf("%s: %s %s", b.Name, b.Manufacturer, b.Model)))
+		section.addIndented(1, "Charge: %s %d%% (%s)", bar(b.ChargePercent, 100, barWidth, glyphs), b.ChargePercent, valueOrUnknown(b.State))
+		if b.CapacityUnit != "" {
+			section.addIndented(1, "Capacity: %.1f %s of %.1f %s design (%.1f%% health)",
+				b.FullCapacity, b.CapacityUnit, b.DesignCapacity, b.CapacityUnit, b.healthPercent())
+		}
+		if b.CycleCount > 0 {
+			section.addIndented(1, "Cycle Count: %d", b.CycleCount)
+		}
+	}
+	return section
+}
+
+// bar draws value as a share of total in width cells of full and empty
+// blocks, rounded to the nearest cell. Any value above zero fills at least
+// one cell so it doesn't look empty.
+func bar(value, total, width int, glyphs *glyphSet) string {
+	filled := 0
+	if total > 0 {
+		filled = max(0, min(width, (value*width+total/2)/total))
+	}
+	if value > 0 && filled == 0 {
+		filled = 1
+	}
+	return strings.Repeat(glyphs.barFull, filled) + strings.Repeat(glyphs.barEmpty, width-filled)
+}
+
+// formatSections renders sections as plain, left-aligned text, one blank
+// line apart. Nested lines are indented two spaces per level.
+func formatSections(sections []reportSection) string {
+	var b strings.Builder
+	for i, section := range sections {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString(section.Title + "\n")
+		for _, line := range section.Lines {
+			b.WriteString(strings.Repeat("  ", line.Indent+1) + line.Text + "\n")
+		}
+	}
+	return b.String()
+}
+
+// deviceSections returns the sections of the pages after the CPU ones,
+// RAM through battery, in menu order. Battery is left out on machines
+// without one, as its page is.
+func deviceSections(hwInfo *HardwareInfo, glyphs *glyphSet) []reportSection {
+	sections := ramSections(&hwInfo.RAM)
+	sections = append(sections,
+		diskSection(&hwInfo.Disk),
+		gpuSection(&hwInfo.GPU),
+		networkSection(&hwInfo.Network),
+		pciSection(&hwInfo.PCI),
+		usbSection(&hwInfo.USB),
+		sensorSection(&hwInfo.Sensors, glyphs),
+	)
+	sections = append(sections, systemSections(&hwInfo.System)...)
+	if len(hwInfo.Battery.Batteries) > 0 {
+		sections = append(sections, batterySection(&hwInfo.Battery, glyphs))
+	}
+	return sections
+}
+
+// writeTextReport writes the CPU page's sections as plain, left-aligned text.
+func writeTextReport(w io.Writer, hwInfo *HardwareInfo) error {
+	const textWidth = 78
+
+	sections := cpuSections(&hwInfo.CPU, selectedGlyphs())
+	sections = append(sections, cpuFeatureSections(&hwInfo.CPU, textWidth)...)
+
+	_, err := io.WriteString(w, formatSections(sections))
+	return err
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// sensorKinds are the hwmon input types read, in display order, with the
+// divisor from their sysfs units to the unit shown.
+var sensorKinds = []struct {
+	prefix  string
+	kind    string
+	divisor float64
+}{
+	{"temp", "temperature", 1000}, // Millidegrees Celsius
+	{"fan", "fan", 1},             // RPM
+	{"in", "voltage", 1000},       // Millivolts
+}
+
+// collectSensorInfo reads every temperature, fan and voltage input under
+// /sys/class/hwmon, one chip per hwmon device. Chips without any of these
+// inputs are left out; other platforms get an empty list.
+func collectSensorInfo() *SensorInfo {
+	info := &SensorInfo{}
+	hwmons, _ := filepath.Glob("/sys/class/hwmon/hwmon*")
+	sort.Slice(hwmons, func(i, j int) bool {
+		return sensorIndex(hwmons[i]) < sensorIndex(hwmons[j])
+	})
+	for _, hwmon := range hwmons {
+		chip := SensorChip{
+			Name:   readSysfsString(filepath.Join(hwmon, "name")),
+			Device: filepath.Base(hwmon),
+		}
+		for _, k := range sensorKinds {
+			inputs, _ := filepath.Glob(filepath.Join(hwmon, k.prefix+"[0-9]*_input"))
+			sort.Slice(inputs, func(i, j int) bool {
+				return sensorIndex(inputs[i]) < sensorIndex(inputs[j])
+			})
+			for _, input := range inputs {
+				raw, err := strconv.ParseFloat(readSysfsString(input), 64)
+				if err != nil {
+					continue // Disabled or faulty inputs fail to read
+				}
+				label := sensorLabel(input)
+				if label == "" {
+					label = strings.TrimSuffix(filepath.Base(input), "_input")
+				}
+				chip.Sensors = append(chip.Sensors, Sensor{Label: label, Kind: k.kind, Value: raw / k.divisor})
+			}
+		}
+		if len(chip.Sensors) > 0 {
+			info.Chips = append(info.Chips, chip)
+		}
+	}
+	return info
+}
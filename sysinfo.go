@@ -0,0 +1,78 @@
+package main
+
+import (
+	"path/filepath"
+	"strconv"
+)
+
+const dmiPath = "/sys/class/dmi/id"
+
+// chassisTypeNames maps the SMBIOS chassis type codes to their names.
+var chassisTypeNames = map[int]string{
+	1:  "Other",
+	2:  "Unknown",
+	3:  "Desktop",
+	4:  "Low Profile Desktop",
+	5:  "Pizza Box",
+	6:  "Mini Tower",
+	7:  "Tower",
+	8:  "Portable",
+	9:  "Laptop",
+	10: "Notebook",
+	11: "Hand Held",
+	12: "Docking Station",
+	13: "All in One",
+	14: "Sub Notebook",
+	15: "Space-saving",
+	16: "Lunch Box",
+	17: "Main Server Chassis",
+	18: "Expansion Chassis",
+	19: "SubChassis",
+	20: "Bus Expansion Chassis",
+	21: "Peripheral Chassis",
+	22: "RAID Chassis",
+	23: "Rack Mount Chassis",
+	24: "Sealed-case PC",
+	25: "Multi-system Chassis",
+	26: "Compact PCI",
+	27: "Advanced TCA",
+	28: "Blade",
+	29: "Blade Enclosure",
+	30: "Tablet",
+	31: "Convertible",
+	32: "Detachable",
+	33: "IoT Gateway",
+	34: "Embedded PC",
+	35: "Mini PC",
+	36: "Stick PC",
+}
+
+// collectSystemInfo reads the DMI/SMBIOS attributes the kernel exports. The
+// attributes used here are world-readable; anything missing (no DMI tables,
+// non-Linux) is left as an empty string.
+func collectSystemInfo() *SystemInfo {
+	dmi := func(name string) string {
+		return readSysfsString(filepath.Join(dmiPath, name))
+	}
+
+	info := &SystemInfo{
+		SystemVendor: dmi("sys_vendor"),
+		ProductName:  dmi("product_name"),
+		BoardVendor:  dmi("board_vendor"),
+		BoardName:    dmi("board_name"),
+		BoardVersion: dmi("board_version"),
+		BIOSVendor:   dmi("bios_vendor"),
+		BIOSVersion:  dmi("bios_version"),
+		BIOSDate:     dmi("bios_date"),
+	}
+
+	if code, err := strconv.Atoi(dmi("chassis_type")); err == nil {
+		if name, ok := chassisTypeNames[code]; ok {
+			info.ChassisType = name
+		} else {
+			info.ChassisType = "Type " + strconv.Itoa(code)
+		}
+	}
+
+	return info
+}
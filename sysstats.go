@@ -0,0 +1,46 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// collectSystemStats reads the uptime and load averages from /proc.
+func collectSystemStats() (*SystemStats, error) {
+	uptime, err := os.ReadFile("/proc/uptime")
+	if err != nil {
+		return nil, err
+	}
+	loadavg, err := os.ReadFile("/proc/loadavg")
+	if err != nil {
+		return nil, err
+	}
+
+	// /proc/uptime: "<seconds up> <seconds idle>"
+	uptimeFields := strings.Fields(string(uptime))
+	// /proc/loadavg: "<1m> <5m> <15m> <running>/<total> <last pid>"
+	loadFields := strings.Fields(string(loadavg))
+	if len(uptimeFields) < 1 || len(loadFields) < 3 {
+		return nil, fmt.Errorf("unexpected /proc/uptime or /proc/loadavg format")
+	}
+
+	stats := &SystemStats{}
+	values := []*float64{&stats.UptimeSeconds, &stats.Load1, &stats.Load5, &stats.Load15}
+	for i, field := range []string{uptimeFields[0], loadFields[0], loadFields[1], loadFields[2]} {
+		value, err := strconv.ParseFloat(field, 64)
+		if err != nil {
+			return nil, err
+		}
+		*values[i] = value
+	}
+
+	return stats, nil
+}
+
+// formatUptime formats a duration in seconds as "Nd Nh Nm".
+func formatUptime(seconds float64) string {
+	minutes := int64(seconds) / 60
+	return fmt.Sprintf("%dd %dh %dm", minutes/(24*60), minutes/60%24, minutes%60)
+}
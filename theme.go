@@ -0,0 +1,104 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/spf13/cobra"
+)
+
+// theme holds the styles assigned to the package-level style variables.
+type theme struct {
+	normal  tcell.Style
+	reverse tcell.Style
+	title   tcell.Style
+	section tcell.Style
+	border  tcell.Style
+	good    tcell.Style
+	bad     tcell.Style
+}
+
+var amber = tcell.NewRGBColor(255, 176, 0)
+
+var themes = map[string]theme{
+	"classic": {
+		normal:  tcell.StyleDefault.Foreground(tcell.ColorWhite).Background(tcell.ColorBlack),
+		reverse: tcell.StyleDefault.Foreground(tcell.ColorBlack).Background(tcell.ColorWhite),
+		title:   tcell.StyleDefault.Foreground(tcell.ColorYellow).Background(tcell.ColorBlack).Bold(true),
+		section: tcell.StyleDefault.Foreground(tcell.ColorGreen).Background(tcell.ColorBlack),
+		border:  tcell.StyleDefault.Foreground(tcell.ColorWhite).Background(tcell.ColorBlack),
+		good:    tcell.StyleDefault.Foreground(tcell.ColorGreen).Background(tcell.ColorBlack),
+		bad:     tcell.StyleDefault.Foreground(tcell.ColorRed).Background(tcell.ColorBlack).Bold(true),
+	},
+	"mono": {
+		normal:  tcell.StyleDefault.Foreground(tcell.ColorWhite).Background(tcell.ColorBlack),
+		reverse: tcell.StyleDefault.Foreground(tcell.ColorBlack).Background(tcell.ColorWhite),
+		title:   tcell.StyleDefault.Foreground(tcell.ColorWhite).Background(tcell.ColorBlack).Bold(true),
+		section: tcell.StyleDefault.Foreground(tcell.ColorWhite).Background(tcell.ColorBlack),
+		border:  tcell.StyleDefault.Foreground(tcell.ColorWhite).Background(tcell.ColorBlack),
+		good:    tcell.StyleDefault.Foreground(tcell.ColorWhite).Background(tcell.ColorBlack),
+		bad:     tcell.StyleDefault.Foreground(tcell.ColorWhite).Background(tcell.ColorBlack).Bold(true),
+	},
+	"amber": {
+		normal:  tcell.StyleDefault.Foreground(amber).Background(tcell.ColorBlack),
+		reverse: tcell.StyleDefault.Foreground(tcell.ColorBlack).Background(amber),
+		title:   tcell.StyleDefault.Foreground(amber).Background(tcell.ColorBlack).Bold(true),
+		section: tcell.StyleDefault.Foreground(tcell.ColorDarkOrange).Background(tcell.ColorBlack),
+		border:  tcell.StyleDefault.Foreground(amber).Background(tcell.ColorBlack),
+		good:    tcell.StyleDefault.Foreground(amber).Background(tcell.ColorBlack),
+		bad:     tcell.StyleDefault.Foreground(tcell.ColorRed).Background(tcell.ColorBlack).Bold(true),
+	},
+}
+
+// themeNames returns the valid --theme names, sorted.
+func themeNames() []string {
+	names := make([]string, 0, len(themes))
+	for name := range themes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// applyTheme sets the style variables used by the renderer to the named
+// theme.
+func applyTheme(name string) error {
+	t, ok := themes[strings.ToLower(name)]
+	if !ok {
+		return fmt.Errorf("unknown theme %q (valid themes: %s)", name, strings.Join(themeNames(), ", "))
+	}
+	styleNormal = t.normal
+	styleReverse = t.reverse
+	styleTitle = t.title
+	styleSection = t.section
+	styleBorder = t.border
+	styleGood = t.good
+	styleBad = t.bad
+	return nil
+}
+
+// applyNoColor drops every color and attribute so the TUI renders in the
+// terminal's default colors. Reverse video is kept on the selection since it
+// is not a color and the selected menu item would otherwise be invisible.
+func applyNoColor() {
+	styleNormal = tcell.StyleDefault
+	styleReverse = tcell.StyleDefault.Reverse(true)
+	styleTitle = tcell.StyleDefault
+	styleSection = tcell.StyleDefault
+	styleBorder = tcell.StyleDefault
+	styleGood = tcell.StyleDefault
+	styleBad = tcell.StyleDefault
+}
+
+// colorDisabled reports whether color output is turned off. A set NO_COLOR
+// environment variable disables color unless --no-color was given explicitly,
+// in which case the flag's value wins.
+func colorDisabled(cmd *cobra.Command) bool {
+	if cmd.Flags().Changed("no-color") {
+		return noColor
+	}
+	return os.Getenv("NO_COLOR") != ""
+}
@@ -1,15 +1,25 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"retrotui"
+	"runtime/debug"
+	"slices"
 	"sort"
+	"strings"
+	"sync"
 	"syscall"
+	"time"
 
+	"github.com/atotto/clipboard"
 	"github.com/gdamore/tcell/v2"
+	"github.com/mattn/go-runewidth"
 	"github.com/spf13/cobra"
+	"golang.org/x/term"
 )
 
 type Page int
@@ -17,69 +27,403 @@ type Page int
 const (
 	PageSummary Page = iota
 	PageCPU
+	PageFeatures
+	PageCPUID
+	PageRAM
+	PageDisk
+	PageGPU
+	PageNetwork
+	PagePCI
+	PageUSB
+	PageSensors
+	PageSystem
+	PageBattery
 )
 
+// pageDef describes one navigable page.
+type pageDef struct {
+	id       Page
+	name     string // Menu label; lowercased it is also the --page name
+	title    string // Shown in the top border
+	render   func(app *App, width, height int) int
+	sections func(app *App) []reportSection // Plain-text content copied with 'y'
+}
+
+// pages lists every page in menu order. Navigation, the menu, mouse hit
+// testing and --page parsing are all derived from it, so adding a page only
+// means adding an entry here.
+var pages = []pageDef{
+	{PageSummary, "Summary", "HARDWARE SUMMARY", (*App).renderSummary, func(app *App) []reportSection {
+		return summarySections(app.hwInfo)
+	}},
+	{PageCPU, "CPU", "CPU INFORMATION", (*App).renderCPU, func(app *App) []reportSection {
+		return append(cpuSections(&app.hwInfo.CPU, app.glyphs), cpuFeatureSections(&app.hwInfo.CPU, 78)...)
+	}},
+	{PageFeatures, "Features", "CPU FEATURES", (*App).renderFeatures, func(app *App) []reportSection {
+		return []reportSection{app.featureListSection()}
+	}},
+	{PageCPUID, "CPUID", "RAW CPUID", (*App).renderCPUID, func(app *App) []reportSection {
+		return []reportSection{cpuidSection(&app.hwInfo.CPU)}
+	}},
+	{PageRAM, "RAM", "MEMORY INFORMATION", (*App).renderRAM, func(app *App) []reportSection {
+		return ramSections(&app.hwInfo.RAM)
+	}},
+	{PageDisk, "Disk", "DISK INFORMATION", (*App).renderDisk, func(app *App) []reportSection {
+		return []reportSection{diskSection(&app.hwInfo.Disk)}
+	}},
+	{PageGPU, "GPU", "GPU INFORMATION", (*App).renderGPU, func(app *App) []reportSection {
+		return []reportSection{gpuSection(&app.hwInfo.GPU)}
+	}},
+	{PageNetwork, "Network", "NETWORK INTERFACES", (*App).renderNetwork, func(app *App) []reportSection {
+		return []reportSection{networkSection(&app.hwInfo.Network)}
+	}},
+	{PagePCI, "PCI", "PCI DEVICES", (*App).renderPCI, func(app *App) []reportSection {
+		return []reportSection{pciSection(&app.hwInfo.PCI)}
+	}},
+	{PageUSB, "USB", "USB DEVICES", (*App).renderUSB, func(app *App) []reportSection {
+		return []reportSection{usbSection(&app.hwInfo.USB)}
+	}},
+	{PageSensors, "Sensors", "SENSORS", (*App).renderSensors, func(app *App) []reportSection {
+		return []reportSection{sensorSection(&app.hwInfo.Sensors, app.glyphs)}
+	}},
+	{PageSystem, "System", "SYSTEM INFORMATION", (*App).renderSystem, func(app *App) []reportSection {
+		return systemSections(&app.hwInfo.System)
+	}},
+	{PageBattery, "Battery", "BATTERY INFORMATION", (*App).renderBattery, func(app *App) []reportSection {
+		return []reportSection{batterySection(&app.hwInfo.Battery, app.glyphs)}
+	}},
+}
+
+// pageAvailable decides, for pages that only apply to some machines, whether
+// this one has anything to show. Pages not listed are always available.
+var pageAvailable = map[Page]func(hwInfo *HardwareInfo) bool{
+	PageBattery: func(hwInfo *HardwareInfo) bool { return len(hwInfo.Battery.Batteries) > 0 },
+	PageCPUID:   func(hwInfo *HardwareInfo) bool { return len(hwInfo.CPU.RawCPUID) > 0 },
+}
+
+// availablePages returns pages without those pageAvailable rules out for
+// hwInfo. The App navigates this list, so the others drop out of the menu
+// and navigation while pages itself stays complete for --page parsing.
+func availablePages(hwInfo *HardwareInfo) []pageDef {
+	available := make([]pageDef, 0, len(pages))
+	for _, def := range pages {
+		if check, ok := pageAvailable[def.id]; !ok || check(hwInfo) {
+			available = append(available, def)
+		}
+	}
+	return available
+}
+
+// pageIndex returns the position of page in defs, or 0 if it is missing.
+func pageIndex(defs []pageDef, page Page) int {
+	for i, def := range defs {
+		if def.id == page {
+			return i
+		}
+	}
+	return 0
+}
+
+// pageNameList returns the valid --page names in menu order.
+func pageNameList() []string {
+	names := make([]string, 0, len(pages))
+	for _, def := range pages {
+		names = append(names, strings.ToLower(def.name))
+	}
+	return names
+}
+
+// parsePage resolves a --page name, case-insensitively.
+func parsePage(name string) (Page, error) {
+	for _, def := range pages {
+		if strings.EqualFold(def.name, name) {
+			return def.id, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown page %q (valid pages: %s)", name, strings.Join(pageNameList(), ", "))
+}
+
 type App struct {
-	hwInfo      *HardwareInfo
-	currentPage Page
-	screen      tcell.Screen
-	done        chan bool
-	scrollY     int // Scroll offset for current page
+	mu           sync.Mutex // Guards hwInfo and dynamic while refresh mode updates them
+	hwInfo       *HardwareInfo
+	source       string       // Snapshot file hwInfo was loaded from, or "" for this machine
+	dynamic      *DynamicInfo // Readings from refreshLoop not yet merged into hwInfo
+	refreshedAt  time.Time    // When the readings were last collected, shown with --refresh
+	pages        []pageDef    // Pages available on this machine, in menu order
+	currentPage  Page
+	screen       tcell.Screen
+	quit         context.CancelFunc // Ends the program; safe to call any number of times
+	scrollY      int                // Scroll offset for current page
+	pageScroll   map[Page]int       // Scroll offset each page was left at
+	detailPage   Page               // Last page other than Summary, which Tab returns to
+	contentLines int                // Content height of the current page, measured by render
+	searchQuery  string
+	searchActive bool // Typed runes edit searchQuery instead of navigating
+	showHelp     bool // Key help overlay is drawn over the current page
+	helpScroll   int  // First key binding shown when the overlay is too short for all
+	noWrap       bool // Left and Right stop at the first and last page
+	splash       bool // --splash banner is drawn instead of the page
+	glyphs       *glyphSet
+
+	selectedFeature   int             // Index into filteredFeatures shown in the detail pane
+	selectedCategory  int             // Index into the CPU page's sorted feature categories
+	categoryRows      []int           // Content line of each category header, measured by renderCPU
+	collapsed         map[string]bool // CPU page feature categories showing only their header
+	featureSort       featureSortMode
+	featureCols       int           // Column count of the last rendered feature grid
+	featureListHeight int           // Rows available to the feature grid above the detail pane
+	featureGrids      []featureGrid // Feature grids drawn by the last render, for mouse hit-testing
+
+	status    string // Transient message shown in place of the key hints
+	statusSeq int    // Bumped per message so only the latest one's timer clears it
+
+	lineKeyAt  time.Time // When the last Up/Down key arrived, for scroll acceleration
+	lineKeyDir int       // Direction of that key: -1 up, 1 down
+	lineKeyRun int       // Consecutive rapid presses in lineKeyDir
+}
+
+// featureGrid records where a grid of feature names was drawn, so a click
+// can be mapped back to the feature under it with the same column math.
+type featureGrid struct {
+	names    []string
+	x        int // Screen column of the first column
+	line     int // Content line of the first row, before scrolling
+	colWidth int
+	numCols  int
+	bottom   int // First screen row the grid is hidden behind
+}
+
+// Feature grid column widths: auto layout fits columns of at least
+// featureColWidth, and a --feature-columns count is capped so each column
+// keeps minFeatureColWidth.
+const (
+	featureColWidth    = 30
+	maxFeatureCols     = 4
+	minFeatureColWidth = 8
+)
+
+// featureGridColumns lays out a feature grid whose first column starts at
+// screen column x: --feature-columns columns, or as many as fit when it is
+// 0, widened to share the width out to the right border.
+func featureGridColumns(width, x int) (colWidth, numCols int) {
+	available := width - x
+	numCols = featureColumns
+	if numCols == 0 {
+		numCols = min(available/featureColWidth, maxFeatureCols)
+	}
+	numCols = max(1, min(numCols, available/minFeatureColWidth))
+	return max(available/numCols, 1), numCols
+}
+
+// featureAt returns the index into g.names of the feature drawn at (mx, my)
+// with the page scrolled to scrollY.
+func (g featureGrid) featureAt(mx, my, scrollY int) (int, bool) {
+	if my < 2 || my >= g.bottom || mx < g.x {
+		return 0, false
+	}
+	row := my - (g.line + 2 - scrollY)
+	col := (mx - g.x) / g.colWidth
+	if row < 0 || col >= g.numCols {
+		return 0, false
+	}
+	idx := row*g.numCols + col
+	if idx >= len(g.names) {
+		return 0, false
+	}
+	return idx, true
+}
+
+// statusExpired is posted as interrupt data when a status message's time is
+// up; it carries the statusSeq the message was shown with.
+type statusExpired int
+
+// statusDuration is how long a status message replaces the key hints.
+const statusDuration = time.Second
+
+// splashDone is posted as interrupt data when the --splash banner's time
+// is up.
+type splashDone struct{}
+
+// splashDuration is how long the --splash banner shows unless a key is
+// pressed first.
+const splashDuration = 1500 * time.Millisecond
+
+// splashBanner is the --splash boot screen's logo, in plain ASCII so it
+// draws the same with --ascii.
+var splashBanner = []string{
+	` _____    _    ____  _   ___        __`,
+	`| ____|  / \  |  _ \| | | \ \      / /`,
+	`|  _|   / _ \ | |_) | |_| |\ \ /\ / / `,
+	`| |___ / ___ \|  _ <|  _  | \ V  V /  `,
+	`|_____/_/   \_\_| \_\_| |_|  \_/\_/   `,
 }
 
+// Below this size render shows a notice instead of the layout.
+const (
+	minWidth  = 40
+	minHeight = 10
+)
+
 var (
 	styleNormal  = tcell.StyleDefault.Foreground(tcell.ColorWhite).Background(tcell.ColorBlack)
 	styleReverse = tcell.StyleDefault.Foreground(tcell.ColorBlack).Background(tcell.ColorWhite)
 	styleTitle   = tcell.StyleDefault.Foreground(tcell.ColorYellow).Background(tcell.ColorBlack).Bold(true)
 	styleSection = tcell.StyleDefault.Foreground(tcell.ColorGreen).Background(tcell.ColorBlack)
 	styleBorder  = tcell.StyleDefault.Foreground(tcell.ColorWhite).Background(tcell.ColorBlack)
+	styleGood    = tcell.StyleDefault.Foreground(tcell.ColorGreen).Background(tcell.ColorBlack)
+	styleBad     = tcell.StyleDefault.Foreground(tcell.ColorRed).Background(tcell.ColorBlack).Bold(true)
 )
 
 func runTUI(cmd *cobra.Command, args []string) {
-	// Collect hardware info
-	hwInfo, err := CollectHardwareInfo()
+	page, err := parsePage(startPage)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error collecting hardware info: %v\n", err)
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if err := applyTheme(themeName); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if colorDisabled(cmd) {
+		applyNoColor()
+	}
+	if featureColumns < 0 {
+		fmt.Fprintf(os.Stderr, "Error: --feature-columns must be 0 (auto) or more, got %d\n", featureColumns)
 		os.Exit(1)
 	}
 
+	// Piped or redirected output gets the plain-text report instead
+	headless := !tuiEnabled(cmd)
+	if headless && !profileCollection && fromPath == "" {
+		runDump(cmd, args)
+		return
+	}
+
+	// Collect hardware info, or load another machine's from --from
+	var hwInfo *HardwareInfo
+	if fromPath != "" {
+		hwInfo, err = loadSnapshot(fromPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading snapshot: %v\n", err)
+			os.Exit(1)
+		}
+		if headless {
+			if err := writeTextReport(os.Stdout, hwInfo); err != nil {
+				fmt.Fprintf(os.Stderr, "Error writing report: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		}
+	} else {
+		if profileCollection {
+			collectionProfile = &stepProfile{}
+		}
+		hwInfo, err = CollectHardwareInfo()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error collecting hardware info: %v\n", err)
+			os.Exit(1)
+		}
+		writeCollectionProfile(os.Stderr)
+		writeCollectionErrors(os.Stderr, hwInfo.CollectionErrors)
+		if headless {
+			return // --profile-collection --no-tui only prints the timings
+		}
+	}
+
+	// A --page that isn't available here opens the first page instead
+	available := availablePages(hwInfo)
+	page = available[pageIndex(available, page)].id
+
 	// Initialize screen
 	screen, err := retrotui.InitScreen()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error initializing screen: %v\n", err)
-		os.Exit(1)
+		// Without a usable terminal, still print what was collected
+		fmt.Fprintf(os.Stderr, "Error initializing screen: %v; printing the text report instead\n", err)
+		if err := writeTextReport(os.Stdout, hwInfo); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing report: %v\n", err)
+			os.Exit(1)
+		}
+		return
 	}
 	defer retrotui.ExitProgram(screen)
 
-	// Enable mouse support
-	screen.EnableMouse()
+	// Enable mouse support, unless it's left to the terminal's own
+	// text selection
+	if !noMouse {
+		screen.EnableMouse()
+	}
+	if refreshOnFocus {
+		screen.EnableFocus()
+	}
 
 	app := &App{
 		hwInfo:      hwInfo,
-		currentPage: PageSummary,
+		pages:       available,
+		currentPage: page,
 		screen:      screen,
-		done:        make(chan bool),
+		glyphs:      selectedGlyphs(),
 		scrollY:     0,
+		source:      fromPath,
+		refreshedAt: time.Now(),
+		noWrap:      noWrap,
+		splash:      showSplash,
 	}
+	// Runs before the deferred ExitProgram, which would exit with status 0
+	defer app.recoverPanic()
+
+	// Everything runs until a quit key or signal cancels ctx
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+	app.quit = cancel
 
-	// Handle signals
-	sigChan := make(chan os.Signal, 1)
-	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+	// Periodically re-collect hardware info
+	if refreshSeconds > 0 {
+		go app.refreshLoop(ctx, time.Duration(refreshSeconds)*time.Second)
+	}
 
-	go func() {
-		<-sigChan
-		app.done <- true
-	}()
+	if app.splash {
+		time.AfterFunc(splashDuration, func() {
+			screen.PostEvent(tcell.NewEventInterrupt(splashDone{}))
+		})
+	}
 
 	// Main event loop
 	go app.eventLoop()
 
 	app.render()
 
-	<-app.done
+	<-ctx.Done()
+}
+
+// tuiEnabled reports whether to start the TUI. By default it starts only
+// when stdout is a terminal; --tui and --no-tui override that either way.
+func tuiEnabled(cmd *cobra.Command) bool {
+	if cmd.Flags().Changed("no-tui") {
+		return !noTUI
+	}
+	if cmd.Flags().Changed("tui") {
+		return forceTUI
+	}
+	return term.IsTerminal(int(os.Stdout.Fd()))
+}
+
+// recoverPanic, deferred at the top of each TUI goroutine, restores the
+// terminal and reports a panic instead of leaving the terminal in raw mode.
+// It finalizes the screen itself because retrotui.ExitProgram exits with
+// status 0 before the panic could be printed.
+func (app *App) recoverPanic() {
+	r := recover()
+	if r == nil {
+		return
+	}
+	app.screen.Fini()
+	fmt.Fprintf(os.Stderr, "panic: %v\n\n%s", r, debug.Stack())
+	os.Exit(2)
 }
 
 func (app *App) eventLoop() {
+	defer app.recoverPanic()
+
 	for {
 		ev := app.screen.PollEvent()
 		if ev == nil {
@@ -88,40 +432,316 @@ func (app *App) eventLoop() {
 
 		switch ev := ev.(type) {
 		case *tcell.EventKey:
+			if app.splash && ev.Key() != tcell.KeyCtrlC {
+				// Any key skips the banner
+				app.splash = false
+				app.render()
+				continue
+			}
+			if app.showHelp && ev.Key() != tcell.KeyCtrlC {
+				// Scroll keys move a clipped overlay; any other key dismisses it
+				if !app.scrollHelp(ev) {
+					app.showHelp = false
+				}
+				app.render()
+				continue
+			}
+			if app.searchActive && ev.Key() != tcell.KeyCtrlC {
+				app.handleSearchKey(ev)
+				continue
+			}
 			switch ev.Key() {
 			case tcell.KeyEscape, tcell.KeyCtrlC:
-				app.done <- true
+				app.quit()
 				return
 			case tcell.KeyLeft:
 				app.prevPage()
-				app.scrollY = 0 // Reset scroll when changing pages
-				app.render()
 			case tcell.KeyRight:
 				app.nextPage()
-				app.scrollY = 0 // Reset scroll when changing pages
-				app.render()
 			case tcell.KeyUp:
-				if app.scrollY > 0 {
-					app.scrollY--
-					app.render()
-				}
+				app.lineUp(app.lineStep(ev, -1))
 			case tcell.KeyDown:
-				app.scrollY++
-				app.render()
+				app.lineDown(app.lineStep(ev, 1))
+			case tcell.KeyPgUp:
+				app.scrollTo(app.scrollY - app.pageStep())
+			case tcell.KeyPgDn:
+				app.scrollTo(app.scrollY + app.pageStep())
+			case tcell.KeyEnter:
+				if app.currentPage == PageCPU {
+					app.toggleCategory()
+				}
+			case tcell.KeyTab:
+				app.toggleSummary()
+			case tcell.KeyHome:
+				app.scrollTo(0)
+			case tcell.KeyEnd:
+				app.scrollTo(app.maxScroll())
 			case tcell.KeyRune:
-				if ev.Rune() == 'q' || ev.Rune() == 'Q' {
-					app.done <- true
+				switch ev.Rune() {
+				case 'q', 'Q':
+					app.quit()
 					return
+				case 'h':
+					app.prevPage()
+				case 'l':
+					app.nextPage()
+				case 'k':
+					app.lineUp(app.lineStep(ev, -1))
+				case 'j':
+					app.lineDown(app.lineStep(ev, 1))
+				case '/':
+					if app.currentPage == PageFeatures {
+						app.searchActive = true
+						app.render()
+					}
+				case '[':
+					if app.currentPage == PageCPU {
+						app.selectCategory(app.selectedCategory - 1)
+					}
+				case ']':
+					if app.currentPage == PageCPU {
+						app.selectCategory(app.selectedCategory + 1)
+					}
+				case 's':
+					if app.currentPage == PageFeatures {
+						app.featureSort = (app.featureSort + 1) % featureSortModes
+						app.selectedFeature = 0
+						app.scrollY = 0
+						app.render()
+					}
+				case 'y':
+					app.copyPage()
+				case '?':
+					app.showHelp = true
+					app.helpScroll = 0
+					app.render()
+				case '1', '2', '3', '4', '5', '6', '7', '8', '9':
+					// Jump straight to the page at that menu position
+					if idx := int(ev.Rune() - '1'); idx < len(app.pages) {
+						app.showPage(app.pages[idx].id)
+					}
 				}
 			}
 		case *tcell.EventMouse:
 			app.handleMouse(ev)
+		case *tcell.EventFocus:
+			// Only reported with --refresh-on-focus; catch up on the
+			// readings missed while the terminal was in the background
+			if ev.Focused {
+				go func() {
+					defer app.recoverPanic()
+					app.refreshDynamic()
+				}()
+			}
+		case *tcell.EventInterrupt:
+			// Posted by refreshLoop after new dynamic readings are stored,
+			// or by showStatus when its message expires
+			if seq, ok := ev.Data().(statusExpired); ok && int(seq) == app.statusSeq {
+				app.status = ""
+			}
+			if _, ok := ev.Data().(splashDone); ok {
+				app.splash = false
+			}
+			app.render()
 		case *tcell.EventResize:
 			app.render()
+			// A taller terminal may leave the offset past the new bottom
+			if app.scrollY > app.maxScroll() {
+				app.scrollY = app.maxScroll()
+				app.render()
+			}
+		}
+	}
+}
+
+// Holding Up or Down accelerates: key repeats arriving within
+// lineKeyRepeatWindow of each other scroll 2 lines at a time after
+// lineKeyRun2 of them and 4 after lineKeyRun4, back to 1 after a pause.
+const (
+	lineKeyRepeatWindow = 150 * time.Millisecond
+	lineKeyRun2         = 8
+	lineKeyRun4         = 24
+)
+
+// lineStep returns how many lines an Up (dir -1) or Down (dir 1) key event
+// moves, counting how long the key has been repeating.
+func (app *App) lineStep(ev *tcell.EventKey, dir int) int {
+	when := ev.When()
+	if dir == app.lineKeyDir && when.Sub(app.lineKeyAt) < lineKeyRepeatWindow {
+		app.lineKeyRun++
+	} else {
+		app.lineKeyRun = 0
+	}
+	app.lineKeyAt, app.lineKeyDir = when, dir
+
+	switch {
+	case app.lineKeyRun >= lineKeyRun4:
+		return 4
+	case app.lineKeyRun >= lineKeyRun2:
+		return 2
+	default:
+		return 1
+	}
+}
+
+// lineUp scrolls up n lines, or moves the selection on the Features page.
+func (app *App) lineUp(n int) {
+	if app.currentPage == PageFeatures {
+		app.selectFeature(app.selectedFeature - n)
+	} else if app.scrollY > 0 {
+		app.scrollY = max(app.scrollY-n, 0)
+		app.render()
+	}
+}
+
+// lineDown scrolls down n lines, or moves the selection on the Features page.
+func (app *App) lineDown(n int) {
+	if app.currentPage == PageFeatures {
+		app.selectFeature(app.selectedFeature + n)
+	} else if app.scrollY < app.maxScroll() {
+		app.scrollY = min(app.scrollY+n, app.maxScroll())
+		app.render()
+	}
+}
+
+// selectCategory moves the CPU page's category cursor to idx, scrolling its
+// header into view.
+func (app *App) selectCategory(idx int) {
+	if len(app.categoryRows) == 0 {
+		return
+	}
+	app.selectedCategory = max(0, min(idx, len(app.categoryRows)-1))
+	row := app.categoryRows[app.selectedCategory]
+	if row < app.scrollY {
+		app.scrollY = row
+	} else if row >= app.scrollY+app.visibleLines() {
+		app.scrollY = row - app.visibleLines() + 1
+	}
+	app.render()
+}
+
+// toggleCategory collapses or expands the selected CPU page category.
+func (app *App) toggleCategory() {
+	names := sortedCategoryNames(app.hwInfo.CPU.FeatureCategories)
+	if app.selectedCategory >= len(names) {
+		return
+	}
+	if app.collapsed == nil {
+		app.collapsed = make(map[string]bool)
+	}
+	name := names[app.selectedCategory]
+	app.collapsed[name] = !app.collapsed[name]
+	app.render()
+	// Collapsing can leave the offset past the new bottom
+	if app.scrollY > app.maxScroll() {
+		app.scrollY = app.maxScroll()
+		app.render()
+	}
+}
+
+// handleSearchKey edits the feature filter while search mode is active.
+// Enter keeps the filter, Esc clears it.
+func (app *App) handleSearchKey(ev *tcell.EventKey) {
+	switch ev.Key() {
+	case tcell.KeyEnter:
+		app.searchActive = false
+	case tcell.KeyEscape:
+		app.searchActive = false
+		app.searchQuery = ""
+	case tcell.KeyBackspace, tcell.KeyBackspace2:
+		if query := []rune(app.searchQuery); len(query) > 0 {
+			app.searchQuery = string(query[:len(query)-1])
+		}
+	case tcell.KeyRune:
+		app.searchQuery += string(ev.Rune())
+	default:
+		return
+	}
+	app.scrollY = 0
+	app.selectedFeature = 0
+	app.render()
+}
+
+// selectFeature moves the feature selection to idx, clamped to the filtered
+// list, and scrolls the grid so the selected row stays visible.
+func (app *App) selectFeature(idx int) {
+	count := len(app.filteredFeatures())
+	app.selectedFeature = max(0, min(idx, count-1))
+
+	if app.featureCols > 0 && app.featureListHeight > 0 {
+		row := app.selectedFeature / app.featureCols
+		if row == 0 {
+			app.scrollY = 0 // Keep the section title in view
+		} else if line := row + 1; line < app.scrollY {
+			app.scrollY = line
+		} else if line >= app.scrollY+app.featureListHeight {
+			app.scrollY = line - app.featureListHeight + 1
+		}
+	}
+	app.render()
+}
+
+// copyPage puts the current page's text on the system clipboard, formatted
+// as in the text exports rather than as drawn.
+func (app *App) copyPage() {
+	app.mu.Lock()
+	text := formatSections(app.currentPageDef().sections(app))
+	app.mu.Unlock()
+
+	// On Linux this needs xclip, xsel or wl-copy; a headless box has none
+	if clipboard.Unsupported {
+		app.showStatus("Clipboard unavailable")
+		return
+	}
+	if err := clipboard.WriteAll(text); err != nil {
+		app.showStatus("Copy failed: " + err.Error())
+		return
+	}
+	app.showStatus("Copied!")
+}
+
+// showStatus shows msg in place of the key hints for statusDuration.
+func (app *App) showStatus(msg string) {
+	app.statusSeq++
+	app.status = msg
+	seq := statusExpired(app.statusSeq)
+	time.AfterFunc(statusDuration, func() {
+		app.screen.PostEvent(tcell.NewEventInterrupt(seq))
+	})
+	app.render()
+}
+
+// refreshLoop re-collects the dynamic readings (clock speeds, temperatures,
+// memory usage) on every tick until ctx is done. Static information is
+// collected once at startup. Rendering is left to the event loop so the
+// screen is only drawn from one goroutine.
+func (app *App) refreshLoop(ctx context.Context, interval time.Duration) {
+	defer app.recoverPanic()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			app.refreshDynamic()
 		}
 	}
 }
 
+// refreshDynamic re-collects the dynamic readings and asks the event loop
+// to draw them. It runs off the event loop, since collection takes a while.
+func (app *App) refreshDynamic() {
+	dynamic := collectDynamicInfo()
+	app.mu.Lock()
+	app.dynamic = dynamic
+	app.refreshedAt = time.Now()
+	app.mu.Unlock()
+	app.screen.PostEvent(tcell.NewEventInterrupt(nil))
+}
+
 func (app *App) handleMouse(ev *tcell.EventMouse) {
 	width, height := app.screen.Size()
 	mx, my := ev.Position()
@@ -136,554 +756,1211 @@ func (app *App) handleMouse(ev *tcell.EventMouse) {
 		return
 	}
 	if buttons&tcell.WheelDown != 0 {
-		app.scrollY++
-		app.render()
+		if app.scrollY < app.maxScroll() {
+			app.scrollY++
+			app.render()
+		}
 		return
 	}
 
 	// Handle mouse clicks on menu items (menu is inside border)
-	if buttons&tcell.Button1 != 0 && (my == height-2 || my == height-3) {
-		// Clicked on menu bar
-		menuItems := []string{"Summary", "CPU"}
-		menuWidth := 0
-		for _, item := range menuItems {
-			menuWidth += len(item) + 3
-		}
-		menuWidth -= 1
-		startX := (width - menuWidth) / 2
-		if startX < 2 {
-			startX = 2
-		}
-
-		x := startX
-		for i, item := range menuItems {
-			itemWidth := len(item) + 3
-			if mx >= x && mx < x+itemWidth {
-				app.currentPage = Page(i)
-				app.scrollY = 0
-				app.render()
+	if buttons&tcell.Button1 != 0 {
+		if page, ok := app.menuItemAt(mx, my, width, height); ok {
+			app.showPage(page)
+			return
+		}
+		for _, grid := range app.featureGrids {
+			if idx, ok := grid.featureAt(mx, my, app.scrollY); ok {
+				app.clickFeature(grid.names[idx], idx)
 				return
 			}
-			x += itemWidth
 		}
 	}
 }
 
-func (app *App) nextPage() {
-	totalPages := 2 // Summary, CPU
-	app.currentPage = (app.currentPage + 1) % Page(totalPages)
+// clickFeature selects a clicked feature. The Features page grid selects it
+// in place; a CPU page grid opens the Features page on it, unfiltered, so
+// its description shows in the detail pane.
+func (app *App) clickFeature(name string, idx int) {
+	if app.currentPage == PageFeatures {
+		app.selectFeature(idx)
+		return
+	}
+	app.searchQuery = ""
+	app.searchActive = false
+	app.showPage(PageFeatures) // Lays out the grid selectFeature scrolls
+	app.selectFeature(slices.Index(app.filteredFeatures(), name))
 }
 
-func (app *App) prevPage() {
-	totalPages := 2
-	app.currentPage = (app.currentPage - 1 + Page(totalPages)) % Page(totalPages)
+// menuItem is a page label's position in the menu bar.
+type menuItem struct {
+	page  Page
+	label string
+	x     int
 }
 
-func (app *App) render() {
-	app.screen.Clear()
-
-	// Get terminal dimensions
-	width, height := app.screen.Size()
+// menuLayout places the "[Name]" labels of every available page centered
+// on the menu row. When they don't all fit, it shows the run of labels
+// around the current page that does, with "<" and ">" markers standing for
+// the hidden neighbours on each side. renderMenu draws from it and
+// menuItemAt hit-tests against it, so a click always lands on exactly the
+// label that was drawn there; clicking a marker steps one page that way.
+func (app *App) menuLayout(width int) []menuItem {
+	labelWidth := func(i int) int { return len(app.pages[i].name) + 2 }
+
+	menuWidth := -1
+	for i := range app.pages {
+		menuWidth += labelWidth(i) + 1
+	}
 
-	// Fill background with black
-	for y := 0; y < height; y++ {
-		for x := 0; x < width; x++ {
-			app.screen.SetContent(x, y, ' ', nil, styleNormal)
+	// Labels go in columns [left, right), inside the border's margin
+	first, last := 0, len(app.pages)-1
+	left, right := 2, width-2
+	if menuWidth > right-left && len(app.pages) > 0 {
+		left, right = left+2, right-2 // Room for the markers
+		first = pageIndex(app.pages, app.currentPage)
+		last = first
+		menuWidth = labelWidth(first)
+		for grew := true; grew; {
+			grew = false
+			if last+1 < len(app.pages) && menuWidth+1+labelWidth(last+1) <= right-left {
+				last++
+				menuWidth += 1 + labelWidth(last)
+				grew = true
+			}
+			if first > 0 && menuWidth+1+labelWidth(first-1) <= right-left {
+				first--
+				menuWidth += 1 + labelWidth(first)
+				grew = true
+			}
 		}
 	}
 
-	// Draw border around the app (includes title in top border)
-	app.drawBorder(width, height)
-
-	// Render content based on current page
-	switch app.currentPage {
-	case PageSummary:
-		app.renderSummary(width, height)
-	case PageCPU:
-		app.renderCPU(width, height)
+	items := make([]menuItem, 0, last-first+3)
+	if first > 0 {
+		items = append(items, menuItem{page: app.pages[first-1].id, label: "<", x: left - 2})
 	}
-
-	// Render menu at bottom (last line)
-	app.renderMenu(width, height)
-
-	app.screen.Show()
+	x := max((width-menuWidth)/2, left)
+	for i := first; i <= last; i++ {
+		items = append(items, menuItem{page: app.pages[i].id, label: "[" + app.pages[i].name + "]", x: x})
+		x += labelWidth(i) + 1
+	}
+	if last < len(app.pages)-1 {
+		items = append(items, menuItem{page: app.pages[last+1].id, label: ">", x: right + 1})
+	}
+	return items
 }
 
-func (app *App) drawBorder(width, height int) {
-	// Box drawing characters (single line)
-	topLeft := '┌'
-	topRight := '┐'
-	bottomLeft := '└'
-	bottomRight := '┘'
-	horizontal := '─'
-	vertical := '│'
-	doubleHorizontal := '═'
-
-	// Get title for top border
-	titles := map[Page]string{
-		PageSummary: "HARDWARE SUMMARY",
-		PageCPU:     "CPU INFORMATION",
+// menuItemAt returns the page whose menu label is drawn at (mx, my).
+func (app *App) menuItemAt(mx, my, width, height int) (Page, bool) {
+	if my != height-2 || mx >= width-1 {
+		return 0, false
 	}
-	title := titles[app.currentPage]
-	if title == "" {
-		title = "HARDWARE INFORMATION"
+	for _, item := range app.menuLayout(width) {
+		if mx >= item.x && mx < item.x+len(item.label) {
+			return item.page, true
+		}
 	}
-	titlePart := "[ " + title + " ]"
+	return 0, false
+}
 
-	// Top border with integrated title
-	app.screen.SetContent(0, 0, topLeft, nil, styleBorder)
+// visibleLines returns how many content rows fit between the top border and
+// the instruction line.
+func (app *App) visibleLines() int {
+	_, height := app.screen.Size()
+	return height - 6
+}
 
-	// Calculate title position (centered)
-	titleStart := (width - len(titlePart)) / 2
-	titleEnd := titleStart + len(titlePart)
+// maxScroll returns the largest scroll offset that still keeps the last line
+// of the current page visible.
+func (app *App) maxScroll() int {
+	return max(0, app.contentLines-app.visibleLines())
+}
 
-	for x := 1; x < width-1; x++ {
-		if x >= titleStart && x < titleEnd {
-			// Draw title character - brackets in white, title text in yellow
-			idx := x - titleStart
-			ch := rune(titlePart[idx])
-			if ch == '[' || ch == ']' || ch == ' ' {
-				app.screen.SetContent(x, 0, ch, nil, styleBorder)
-			} else {
-				app.screen.SetContent(x, 0, ch, nil, styleTitle)
-			}
-		} else {
-			// Draw double horizontal line around title area (white)
-			app.screen.SetContent(x, 0, doubleHorizontal, nil, styleBorder)
-		}
-	}
-	app.screen.SetContent(width-1, 0, topRight, nil, styleBorder)
+// pageStep returns how far PgUp/PgDn scroll, keeping one line of overlap.
+func (app *App) pageStep() int {
+	return max(1, app.visibleLines()-1)
+}
 
-	// Bottom border
-	app.screen.SetContent(0, height-1, bottomLeft, nil, styleBorder)
-	for x := 1; x < width-1; x++ {
-		app.screen.SetContent(x, height-1, horizontal, nil, styleBorder)
+// scrollTo moves the scroll offset to y, clamped to the page content, and
+// redraws if it changed.
+func (app *App) scrollTo(y int) {
+	y = max(0, min(y, app.maxScroll()))
+	if y != app.scrollY {
+		app.scrollY = y
+		app.render()
 	}
-	app.screen.SetContent(width-1, height-1, bottomRight, nil, styleBorder)
+}
 
-	// Left and right borders
-	for y := 1; y < height-1; y++ {
-		app.screen.SetContent(0, y, vertical, nil, styleBorder)
-		app.screen.SetContent(width-1, y, vertical, nil, styleBorder)
-	}
+// currentPageDef returns the definition of the page being shown.
+func (app *App) currentPageDef() pageDef {
+	return app.pages[pageIndex(app.pages, app.currentPage)]
 }
 
-func (app *App) renderSectionTitle(x, y, width int, title string) {
-	if y < 1 {
+// nextPage shows the next page, wrapping from the last to the first
+// unless --no-wrap is set.
+func (app *App) nextPage() {
+	idx := pageIndex(app.pages, app.currentPage)
+	if app.noWrap && idx == len(app.pages)-1 {
 		return
 	}
+	app.showPage(app.pages[(idx+1)%len(app.pages)].id)
+}
 
-	// Draw: ───[ Title ]───
-	pos := x
+// prevPage shows the previous page, wrapping from the first to the last
+// unless --no-wrap is set.
+func (app *App) prevPage() {
+	idx := pageIndex(app.pages, app.currentPage)
+	if app.noWrap && idx == 0 {
+		return
+	}
+	app.showPage(app.pages[(idx-1+len(app.pages))%len(app.pages)].id)
+}
 
-	// Draw leading dashes
-	for i := 0; i < 3; i++ {
-		app.screen.SetContent(pos, y, '─', nil, styleSection)
-		pos++
+// toggleSummary jumps to the Summary page, or from it back to the last
+// other page shown; the CPU page if there is none yet.
+func (app *App) toggleSummary() {
+	switch {
+	case app.currentPage != PageSummary:
+		app.showPage(PageSummary)
+	case app.detailPage == PageSummary:
+		app.showPage(PageCPU)
+	default:
+		app.showPage(app.detailPage)
 	}
+}
 
-	// Draw [ title ]
-	titlePart := "[ " + title + " ]"
-	for _, ch := range titlePart {
-		app.screen.SetContent(pos, y, ch, nil, styleSection)
-		pos++
+// showPage switches to page at the scroll offset it was left at, clamped
+// in case the terminal has shrunk since, and draws it.
+func (app *App) showPage(page Page) {
+	if app.pageScroll == nil {
+		app.pageScroll = make(map[Page]int)
+	}
+	app.pageScroll[app.currentPage] = app.scrollY
+	if app.currentPage != PageSummary {
+		app.detailPage = app.currentPage
 	}
+	app.currentPage = page
+	app.scrollY = app.pageScroll[page]
 
-	// Draw trailing dashes
-	for i := 0; i < 3; i++ {
-		app.screen.SetContent(pos, y, '─', nil, styleSection)
-		pos++
+	// maxScroll needs the page's height, which render measures
+	app.render()
+	if app.scrollY > app.maxScroll() {
+		app.scrollY = app.maxScroll()
+		app.render()
 	}
 }
 
-func (app *App) renderMenu(width, height int) {
-	menuY := height - 2 // Inside border
-	menuItems := []string{"Summary", "CPU"}
+func (app *App) render() {
+	app.mu.Lock()
+	defer app.mu.Unlock()
 
-	// Calculate menu width
-	menuWidth := 0
-	for _, item := range menuItems {
-		menuWidth += len(item) + 3
+	// Combine the static info with the latest dynamic readings
+	if app.dynamic != nil {
+		app.hwInfo.applyDynamic(app.dynamic)
+		app.dynamic = nil
 	}
-	menuWidth -= 1
 
-	startX := (width - menuWidth) / 2
-	if startX < 2 {
-		startX = 2
-	}
+	// Clear blanks every cell to the default style, which the screen draws
+	// in its own style, so the background needs no separate fill
+	app.screen.SetStyle(styleNormal)
+	app.screen.Clear()
 
-	x := startX
-	for i, item := range menuItems {
-		selected := int(app.currentPage) == i
-		style := styleNormal
-		if selected {
-			style = styleReverse
-		}
-		retrotui.PrintAt(app.screen, x, menuY, fmt.Sprintf("[%s]", item), style)
-		x += len(item) + 3
-	}
+	// Get terminal dimensions
+	width, height := app.screen.Size()
 
-	// Instructions on line above menu
-	instructions := "← → Navigate | ↑ ↓ Scroll | Mouse: Click/Wheel | Q Quit"
-	instX := (width - len(instructions)) / 2
-	if instX < 2 {
-		instX = 2
+	if width < minWidth || height < minHeight {
+		app.renderTooSmall(width, height)
+		app.screen.Show()
+		return
+	}
+	if app.splash {
+		app.renderSplash(width, height)
+		app.screen.Show()
+		return
 	}
-	retrotui.PrintAt(app.screen, instX, menuY-1, instructions, styleNormal)
-}
 
-func (app *App) renderSummary(width, height int) {
-	y := 2 - app.scrollY // Start below title border
-	x := 3
-	contentHeight := height - 4 // Account for border and menu
+	// Draw border around the app (includes title in top border)
+	app.drawBorder(width, height)
 
-	// CPU Summary
-	if y >= 2 && y < contentHeight {
-		app.renderSectionTitle(x, y, width, "CPU")
-	}
-	y++
-	if y >= 2 && y < contentHeight {
-		retrotui.PrintAt(app.screen, x+4, y, fmt.Sprintf("Vendor:     %s", app.hwInfo.CPU.Vendor), styleNormal)
-	}
-	y++
-	if y >= 2 && y < contentHeight {
-		retrotui.PrintAt(app.screen, x+4, y, fmt.Sprintf("Brand:      %s", truncateString(app.hwInfo.CPU.Brand, width-20)), styleNormal)
-	}
-	y++
-	if y >= 2 && y < contentHeight {
-		retrotui.PrintAt(app.screen, x+4, y, fmt.Sprintf("Cores:      %d", app.hwInfo.CPU.Cores), styleNormal)
+	// Render content based on current page
+	app.featureGrids = app.featureGrids[:0]
+	app.contentLines = app.currentPageDef().render(app, width, height)
+
+	// Scrollbar over the right border once the content overflows
+	app.drawScrollbar(width, height)
+
+	// Render menu at bottom (last line)
+	app.renderMenu(width, height)
+
+	if app.showHelp {
+		app.drawHelp(width, height)
 	}
-	y++
-	if y >= 2 && y < contentHeight {
-		retrotui.PrintAt(app.screen, x+4, y, fmt.Sprintf("Threads:    %d", app.hwInfo.CPU.Threads), styleNormal)
+
+	app.screen.Show()
+}
+
+// helpLines lists the key bindings shown by the '?' overlay.
+func (app *App) helpLines() [][2]string {
+	return [][2]string{
+		{app.glyphs.leftRight + " / h l", "Previous / next page"},
+		{"1-9", "Jump to page by menu position"},
+		{app.glyphs.upDown + " / k j", "Scroll (move selection on Features)"},
+		{"PgUp PgDn", "Scroll one page"},
+		{"Home End", "Jump to top / bottom"},
+		{"Tab", "Switch between Summary and the last other page"},
+		{"/", "Search features (Features page)"},
+		{"s", "Sort features by name, category or vendor"},
+		{"[ ]", "Select feature category (CPU page)"},
+		{"Enter", "Collapse / expand the category (CPU page)"},
+		{"y", "Copy the page as text to the clipboard"},
+		{"Mouse", "Click menu items or features, wheel to scroll"},
+		{"?", "Toggle this help"},
+		{"q Esc", "Quit"},
 	}
-	y += 2
+}
 
-	// Feature count summary
-	if y >= 2 && y < contentHeight {
-		app.renderSectionTitle(x, y, width, "Features")
+// helpRows returns how many key bindings fit in the help box on a screen
+// of the given height: all of them, or fewer when the box is clamped.
+func (app *App) helpRows(height int) int {
+	return max(min(len(app.helpLines()), height-6), 1)
+}
+
+// scrollHelp moves the help overlay for the scroll keys and reports
+// whether ev was one of them.
+func (app *App) scrollHelp(ev *tcell.EventKey) bool {
+	_, height := app.screen.Size()
+	rows := app.helpRows(height)
+	maxScroll := len(app.helpLines()) - rows
+	switch {
+	case ev.Key() == tcell.KeyUp || ev.Key() == tcell.KeyRune && ev.Rune() == 'k':
+		app.helpScroll--
+	case ev.Key() == tcell.KeyDown || ev.Key() == tcell.KeyRune && ev.Rune() == 'j':
+		app.helpScroll++
+	case ev.Key() == tcell.KeyPgUp:
+		app.helpScroll -= rows
+	case ev.Key() == tcell.KeyPgDn:
+		app.helpScroll += rows
+	case ev.Key() == tcell.KeyHome:
+		app.helpScroll = 0
+	case ev.Key() == tcell.KeyEnd:
+		app.helpScroll = maxScroll
+	default:
+		return false
 	}
-	y++
-	if y >= 2 && y < contentHeight {
-		retrotui.PrintAt(app.screen, x+4, y, fmt.Sprintf("Total Features: %d", len(app.hwInfo.CPU.Features)), styleNormal)
+	if maxScroll <= 0 {
+		// Everything is already shown, so the key closes the overlay
+		app.helpScroll = 0
+		return false
 	}
-	y++
-	if y >= 2 && y < contentHeight {
-		retrotui.PrintAt(app.screen, x+4, y, fmt.Sprintf("Categories:     %d", len(app.hwInfo.CPU.FeatureCategories)), styleNormal)
+	app.helpScroll = max(0, min(app.helpScroll, maxScroll))
+	return true
+}
+
+// drawHelp draws the key help as a centered box over whatever render drew
+// underneath, so dismissing it is just a normal re-render. The box is
+// clamped to the screen; key bindings that don't fit scroll with the
+// arrow keys.
+func (app *App) drawHelp(width, height int) {
+	const keyWidth = 12
+
+	helpLines := app.helpLines()
+	rows := app.helpRows(height)
+	footer := "Press any key to close"
+	if rows < len(helpLines) {
+		footer = app.glyphs.upDown + " Scroll | Any other key closes"
 	}
-	y += 2
+	start := max(0, min(app.helpScroll, len(helpLines)-rows))
 
-	// Cache summary
-	if len(app.hwInfo.CPU.CacheDetails) > 0 {
-		if y >= 2 && y < contentHeight {
-			app.renderSectionTitle(x, y, width, "Cache")
-		}
-		y++
-		for _, cache := range app.hwInfo.CPU.CacheDetails {
-			if y >= contentHeight {
-				break
+	boxWidth := runewidth.StringWidth(footer)
+	for _, line := range helpLines {
+		boxWidth = max(boxWidth, keyWidth+runewidth.StringWidth(line[1]))
+	}
+	boxWidth = min(boxWidth+4, width) // Border and one column of padding each side
+	boxHeight := min(len(helpLines)+6, height)
+
+	left := max((width-boxWidth)/2, 0)
+	top := max((height-boxHeight)/2, 0)
+	right := left + boxWidth - 1
+	bottom := top + boxHeight - 1
+
+	for y := top; y <= bottom; y++ {
+		for x := left; x <= right; x++ {
+			ch := ' '
+			switch {
+			case y == top && x == left:
+				ch = app.glyphs.topLeft
+			case y == top && x == right:
+				ch = app.glyphs.topRight
+			case y == bottom && x == left:
+				ch = app.glyphs.bottomLeft
+			case y == bottom && x == right:
+				ch = app.glyphs.bottomRight
+			case y == top || y == bottom:
+				ch = app.glyphs.horizontal
+			case x == left || x == right:
+				ch = app.glyphs.vertical
 			}
-			if y >= 2 {
-				retrotui.PrintAt(app.screen, x+4, y, fmt.Sprintf("L%d %s: %d KB", cache.Level, cache.Type, cache.SizeKB), styleNormal)
+			style := styleNormal
+			if ch != ' ' {
+				style = styleBorder
 			}
-			y++
+			app.screen.SetContent(x, y, ch, nil, style)
 		}
 	}
-}
 
-func (app *App) renderCPU(width, height int) {
-	y := 2 - app.scrollY // Start below title border
-	x := 3
-	contentHeight := height - 4 // Account for border and menu
+	title := "[ HELP ]"
+	retrotui.PrintAt(app.screen, left+(boxWidth-len(title))/2, top, title, styleTitle)
+	for i, line := range helpLines[start : start+rows] {
+		y := top + 2 + i
+		retrotui.PrintAt(app.screen, left+2, y, line[0], styleSection)
+		retrotui.PrintAt(app.screen, left+2+keyWidth, y, truncateString(line[1], boxWidth-4-keyWidth), styleNormal)
+	}
+	footer = truncateString(footer, boxWidth-4)
+	retrotui.PrintAt(app.screen, left+(boxWidth-runewidth.StringWidth(footer))/2, bottom-1, footer, styleNormal)
+}
 
-	// Basic Info
-	if y >= 2 && y < contentHeight {
-		app.renderSectionTitle(x, y, width, "Basic Information")
+// renderTooSmall replaces the whole layout with a centered notice until
+// the terminal is resized to at least minWidth x minHeight.
+func (app *App) renderTooSmall(width, height int) {
+	lines := []string{
+		"Terminal too small",
+		fmt.Sprintf("%dx%d, need %dx%d", width, height, minWidth, minHeight),
 	}
-	y++
-	if y >= 2 && y < contentHeight {
-		retrotui.PrintAt(app.screen, x+4, y, fmt.Sprintf("Vendor:        %s", app.hwInfo.CPU.Vendor), styleNormal)
+	top := max((height-len(lines))/2, 0)
+	for i, line := range lines {
+		line = truncateString(line, width)
+		x := max((width-runewidth.StringWidth(line))/2, 0)
+		retrotui.PrintAt(app.screen, x, top+i, line, styleNormal)
 	}
-	y++
-	if y >= 2 && y < contentHeight {
-		retrotui.PrintAt(app.screen, x+4, y, fmt.Sprintf("Brand:         %s", truncateString(app.hwInfo.CPU.Brand, width-25)), styleNormal)
+}
+
+// renderSplash draws the --splash boot screen: the banner with the tool's
+// name and the CPU brand, centered.
+func (app *App) renderSplash(width, height int) {
+	lines := append([]string{}, splashBanner...)
+	lines = append(lines, "", "Hardware information tool", "", app.hwInfo.CPU.Brand)
+	top := max((height-len(lines))/2, 0)
+	for i, line := range lines {
+		style := styleNormal
+		if i < len(splashBanner) {
+			style = styleTitle
+		}
+		line = truncateString(line, width)
+		x := max((width-runewidth.StringWidth(line))/2, 0)
+		retrotui.PrintAt(app.screen, x, top+i, line, style)
 	}
-	y++
-	if y >= 2 && y < contentHeight {
-		retrotui.PrintAt(app.screen, x+4, y, fmt.Sprintf("Model:         %s", app.hwInfo.CPU.Model), styleNormal)
+}
+
+func (app *App) drawBorder(width, height int) {
+	// Box drawing characters (single line)
+	topLeft := app.glyphs.topLeft
+	topRight := app.glyphs.topRight
+	bottomLeft := app.glyphs.bottomLeft
+	bottomRight := app.glyphs.bottomRight
+	horizontal := app.glyphs.horizontal
+	vertical := app.glyphs.vertical
+	doubleHorizontal := app.glyphs.titleHorizontal
+
+	// Get title for top border
+	title := app.currentPageDef().title
+	if title == "" {
+		title = "HARDWARE INFORMATION"
 	}
-	y++
-	if y >= 2 && y < contentHeight {
-		retrotui.PrintAt(app.screen, x+4, y, fmt.Sprintf("Family:        %d", app.hwInfo.CPU.Family), styleNormal)
+	// A snapshot's file name is shortened, or left out, to keep the page
+	// title within the border
+	if app.source != "" {
+		name := filepath.Base(app.source)
+		room := width - 2 - runewidth.StringWidth("[ "+title+" - "+" ]")
+		if runewidth.StringWidth(name) > room && room > 3 {
+			name = truncateString(name, room)
+		}
+		if runewidth.StringWidth(name) <= room {
+			title += " - " + name
+		}
 	}
-	y++
-	if y >= 2 && y < contentHeight {
-		retrotui.PrintAt(app.screen, x+4, y, fmt.Sprintf("Model Number:  %d", app.hwInfo.CPU.ModelNumber), styleNormal)
+	titleWidth := runewidth.StringWidth("[ " + title + " ]")
+	if titleWidth > width-2 {
+		// Too narrow for the title; draw a plain border
+		title, titleWidth = "", 0
 	}
-	y++
-	if y >= 2 && y < contentHeight {
-		retrotui.PrintAt(app.screen, x+4, y, fmt.Sprintf("Stepping:      %d", app.hwInfo.CPU.Stepping), styleNormal)
+
+	// Top border with integrated title
+	app.screen.SetContent(0, 0, topLeft, nil, styleBorder)
+
+	// Draw double horizontal line around title area (white)
+	for x := 1; x < width-1; x++ {
+		app.screen.SetContent(x, 0, doubleHorizontal, nil, styleBorder)
 	}
-	y++
-	if y >= 2 && y < contentHeight {
-		retrotui.PrintAt(app.screen, x+4, y, fmt.Sprintf("Cores:         %d", app.hwInfo.CPU.Cores), styleNormal)
+
+	// Title centered over it - brackets in white, title text in yellow
+	if title != "" {
+		x := max((width-titleWidth)/2, 1)
+		x = app.drawTitleText(x, "[ ", styleBorder)
+		x = app.drawTitleText(x, title, styleTitle)
+		app.drawTitleText(x, " ]", styleBorder)
 	}
-	y++
-	if y >= 2 && y < contentHeight {
-		retrotui.PrintAt(app.screen, x+4, y, fmt.Sprintf("Threads:       %d", app.hwInfo.CPU.Threads), styleNormal)
+	app.screen.SetContent(width-1, 0, topRight, nil, styleBorder)
+
+	// Bottom border
+	app.screen.SetContent(0, height-1, bottomLeft, nil, styleBorder)
+	for x := 1; x < width-1; x++ {
+		app.screen.SetContent(x, height-1, horizontal, nil, styleBorder)
 	}
-	y++
-	if y >= 2 && y < contentHeight {
-		retrotui.PrintAt(app.screen, x+4, y, fmt.Sprintf("Max Func:      %d", app.hwInfo.CPU.MaxFunc), styleNormal)
+	app.screen.SetContent(width-1, height-1, bottomRight, nil, styleBorder)
+
+	// Left and right borders
+	for y := 1; y < height-1; y++ {
+		app.screen.SetContent(0, y, vertical, nil, styleBorder)
+		app.screen.SetContent(width-1, y, vertical, nil, styleBorder)
 	}
-	y++
-	if y >= 2 && y < contentHeight {
-		retrotui.PrintAt(app.screen, x+4, y, fmt.Sprintf("Max Ext Func:  %d", app.hwInfo.CPU.MaxExtFunc), styleNormal)
+}
+
+// drawTitleText draws text on the top border from column x, one rune per
+// cell with wide runes taking two, and returns the column after it.
+func (app *App) drawTitleText(x int, text string, style tcell.Style) int {
+	for _, r := range text {
+		app.screen.SetContent(x, 0, r, nil, style)
+		x += runewidth.RuneWidth(r)
 	}
-	y++
-	if y >= 2 && y < contentHeight {
-		retrotui.PrintAt(app.screen, x+4, y, fmt.Sprintf("Phys Addr Bits: %d", app.hwInfo.CPU.PhysicalAddrBits), styleNormal)
+	return x
+}
+
+// printClipped prints s at (x, y) like retrotui.PrintAt, but cut to the
+// columns left before the right border so long values never overwrite it.
+func (app *App) printClipped(x, y int, s string, style tcell.Style) {
+	width, _ := app.screen.Size()
+	retrotui.PrintAt(app.screen, x, y, truncateString(s, width-x-1), style)
+}
+
+// drawScrollbar draws a thumb over the right border between the top and
+// bottom borders, sized by the visible share of the content and positioned
+// by the scroll offset. The border itself serves as the track.
+func (app *App) drawScrollbar(width, height int) {
+	maxScroll := app.maxScroll()
+	trackLen := height - 2
+	if maxScroll <= 0 || trackLen <= 0 || app.contentLines <= 0 {
+		return
 	}
-	y++
-	if y >= 2 && y < contentHeight {
-		retrotui.PrintAt(app.screen, x+4, y, fmt.Sprintf("Linear Addr Bits: %d", app.hwInfo.CPU.LinearAddrBits), styleNormal)
+
+	thumbLen := max(1, min(trackLen, trackLen*app.visibleLines()/app.contentLines))
+	thumbStart := 1 + (trackLen-thumbLen)*min(app.scrollY, maxScroll)/maxScroll
+	for y := thumbStart; y < thumbStart+thumbLen; y++ {
+		app.screen.SetContent(width-1, y, app.glyphs.scrollThumb, nil, styleBorder)
 	}
-	y += 2
+}
 
-	// Processor Info Details
-	if y >= 2 && y < contentHeight {
-		app.renderSectionTitle(x, y, width, "Processor Details")
+// renderSections draws report sections starting at row y, one blank line
+// apart, and returns the row after the last one. Rows outside the content
+// area are skipped but still counted.
+func (app *App) renderSections(x, y, width, contentHeight int, sections []reportSection) int {
+	for i, section := range sections {
+		if i > 0 {
+			y++
+		}
+		if y >= 2 && y < contentHeight {
+			app.renderSectionTitle(x, y, width, section.Title)
+		}
+		y++
+		for _, line := range section.Lines {
+			lineX := x + 4 + line.Indent*4
+			rows := []string{line.Text}
+			if line.Wrap {
+				rows = wrapValue(line.Text, width-lineX-1)
+			}
+			for _, row := range rows {
+				if y >= 2 && y < contentHeight {
+					style := styleNormal
+					if line.Heading {
+						style = styleSection
+					}
+					app.printClipped(lineX, y, row, style)
+				}
+				y++
+			}
+		}
 	}
-	y++
-	if y >= 2 && y < contentHeight {
-		retrotui.PrintAt(app.screen, x+4, y, fmt.Sprintf("Max Logical Processors: %d", app.hwInfo.CPU.ProcessorInfo.MaxLogicalProcessors), styleNormal)
+	return y
+}
+
+// wrapValue wraps a "Label:   value" line to maxCols, continuing the value
+// under where it starts. A line that fits, or whose label leaves no room,
+// is returned whole for printClipped to cut.
+func wrapValue(text string, maxCols int) []string {
+	if runewidth.StringWidth(text) <= maxCols {
+		return []string{text}
 	}
-	y++
-	if y >= 2 && y < contentHeight {
-		retrotui.PrintAt(app.screen, x+4, y, fmt.Sprintf("Initial APIC ID: %d", app.hwInfo.CPU.ProcessorInfo.InitialAPICID), styleNormal)
+	label, value, ok := strings.Cut(text, ":")
+	if !ok {
+		return wrapText(text, maxCols)
 	}
-	y++
-	if y >= 2 && y < contentHeight {
-		retrotui.PrintAt(app.screen, x+4, y, fmt.Sprintf("Threads Per Core: %d", app.hwInfo.CPU.ProcessorInfo.ThreadPerCore), styleNormal)
+	valueCol := len(label) + 1 + len(value) - len(strings.TrimLeft(value, " "))
+	if maxCols-valueCol < 10 {
+		return []string{text}
 	}
-	y += 2
 
-	// Model Data Details
-	if y >= 2 && y < contentHeight {
-		app.renderSectionTitle(x, y, width, "Model Data")
+	lines := wrapText(strings.TrimLeft(value, " "), maxCols-valueCol)
+	for i := range lines {
+		prefix := strings.Repeat(" ", valueCol)
+		if i == 0 {
+			prefix = text[:valueCol]
+		}
+		lines[i] = prefix + lines[i]
 	}
-	y++
-	if y >= 2 && y < contentHeight {
-		retrotui.PrintAt(app.screen, x+4, y, fmt.Sprintf("Stepping ID: %d | Model ID: %d | Family ID: %d",
-			app.hwInfo.CPU.ModelData.SteppingID, app.hwInfo.CPU.ModelData.ModelID, app.hwInfo.CPU.ModelData.FamilyID), styleNormal)
+	return lines
+}
+
+func (app *App) renderSectionTitle(x, y, width int, title string) {
+	if y < 1 {
+		return
 	}
-	y++
-	if y >= 2 && y < contentHeight {
-		retrotui.PrintAt(app.screen, x+4, y, fmt.Sprintf("Extended Model: %d | Extended Family: %d",
-			app.hwInfo.CPU.ModelData.ExtendedModel, app.hwInfo.CPU.ModelData.ExtendedFamily), styleNormal)
+
+	// Draw: ───[ Title ]───, clipped at the right border
+	rule := app.glyphs.sectionRule
+	app.printClipped(x, y, rule+"[ "+title+" ]"+rule, styleSection)
+}
+
+func (app *App) renderMenu(width, height int) {
+	menuY := height - 2 // Inside border
+
+	for _, item := range app.menuLayout(width) {
+		style := styleNormal
+		if item.page == app.currentPage {
+			style = styleReverse
+		}
+		app.printClipped(item.x, menuY, item.label, style)
 	}
-	y++
-	if y >= 2 && y < contentHeight {
-		retrotui.PrintAt(app.screen, x+4, y, fmt.Sprintf("Processor Type: %d", app.hwInfo.CPU.ModelData.ProcessorType), styleNormal)
+
+	// Instructions on line above menu
+	arrows := app.glyphs.leftRight + " Navigate | " + app.glyphs.upDown + " Scroll"
+	instructions := arrows + " | Mouse: Click/Wheel | ? Help | Q Quit"
+	if noMouse {
+		instructions = arrows + " | PgUp PgDn Page | ? Help | Q Quit"
+	}
+	if app.searchActive {
+		instructions = "Type to filter | Backspace Edit | Enter Confirm | Esc Clear"
+	} else if app.currentPage == PageFeatures {
+		instructions = arrows + " | / Search | S Sort | ? Help | Q Quit"
+	} else if app.currentPage == PageCPU {
+		instructions = arrows + " | [ ] Category | Enter Collapse | ? Help | Q Quit"
+	}
+	if app.status != "" {
+		instructions = app.status
+	}
+	instWidth := runewidth.StringWidth(instructions)
+	instX := (width - instWidth) / 2
+
+	// The scroll position, once the page overflows, and with --refresh the
+	// time of the last reading are right-aligned on the same line, moving
+	// the hints left to make room
+	status := []string{}
+	if app.maxScroll() > 0 {
+		last := min(app.scrollY+app.visibleLines(), app.contentLines)
+		status = append(status, fmt.Sprintf("Line %d/%d", last, app.contentLines))
+	}
+	if refreshSeconds > 0 {
+		status = append(status, app.refreshedAt.Format("15:04:05"))
+	}
+	right := strings.Join(status, " | ")
+	rightX := width - 2 - len(right)
+	if right != "" {
+		instX = min(instX, rightX-1-instWidth)
+	}
+	if instX < 2 {
+		instX = 2
+	}
+	app.printClipped(instX, menuY-1, instructions, styleNormal)
+	if right != "" {
+		app.printClipped(rightX, menuY-1, right, styleNormal)
 	}
-	y += 2
 
-	// Hybrid Info
-	if app.hwInfo.CPU.HybridInfo.IsHybrid {
-		if y >= 2 && y < contentHeight {
-			app.renderSectionTitle(x, y, width, "Hybrid CPU Information")
+	// Page position dots in the bottom border, filled for the current page
+	dots := make([]string, len(app.pages))
+	for i, def := range app.pages {
+		dots[i] = app.glyphs.dotOff
+		if def.id == app.currentPage {
+			dots[i] = app.glyphs.dotOn
 		}
-		y++
-		if y >= 2 && y < contentHeight {
-			retrotui.PrintAt(app.screen, x+4, y, fmt.Sprintf("Core Type: %s", app.hwInfo.CPU.HybridInfo.CoreType), styleNormal)
-		}
-		y += 2
 	}
+	indicator := " " + strings.Join(dots, " ") + " "
+	if dotsWidth := runewidth.StringWidth(indicator); dotsWidth <= width-4 {
+		retrotui.PrintAt(app.screen, (width-dotsWidth)/2, height-1, indicator, styleBorder)
+	}
+}
+
+func (app *App) renderSummary(width, height int) int {
+	y := 2 - app.scrollY // Start below title border
+	x := 3
+	contentHeight := height - 4 // Account for border and menu
+
+	y = app.renderSections(x, y, width, contentHeight, summarySections(app.hwInfo))
 
-	// Detailed Cache Info
-	if len(app.hwInfo.CPU.CacheDetails) > 0 {
+	return y + app.scrollY - 2
+}
+
+func (app *App) renderCPU(width, height int) int {
+	y := 2 - app.scrollY // Start below title border
+	x := 3
+	contentHeight := height - 4 // Account for border and menu
+
+	y = app.renderSections(x, y, width, contentHeight, cpuSections(&app.hwInfo.CPU, app.glyphs))
+	y++
+
+	y = app.renderTemperature(x, y, width, contentHeight)
+	y = app.renderTopology(x, y, width, contentHeight)
+
+	// Detailed Feature Categories - displayed in columns
+	if len(app.hwInfo.CPU.FeatureCategories) > 0 {
 		if y >= 2 && y < contentHeight {
-			app.renderSectionTitle(x, y, width, "Detailed Cache Information")
+			app.renderSectionTitle(x, y, width, "Supported Features by Category")
 		}
 		y++
-		for _, cache := range app.hwInfo.CPU.CacheDetails {
-			if y >= contentHeight {
-				break
+
+		// Sort category names for consistent ordering
+		categoryNames := sortedCategoryNames(app.hwInfo.CPU.FeatureCategories)
+		app.selectedCategory = max(0, min(app.selectedCategory, len(categoryNames)-1))
+		app.categoryRows = app.categoryRows[:0]
+
+		for i, category := range categoryNames {
+			features := app.hwInfo.CPU.FeatureCategories[category]
+			marker := app.glyphs.expanded
+			if app.collapsed[category] {
+				marker = app.glyphs.bullet
 			}
-			if y < 2 {
-				y++
-				continue
+			style := styleSection
+			if i == app.selectedCategory {
+				style = styleReverse
+			}
+			app.categoryRows = append(app.categoryRows, y+app.scrollY-2)
+			if y >= 2 && y < contentHeight {
+				app.printClipped(x+4, y, fmt.Sprintf("%s %s (%d features)", marker, category, len(features)), style)
 			}
-			retrotui.PrintAt(app.screen, x+4, y, fmt.Sprintf("L%d %s: %d KB, %d-way, %d bytes/line, %d sets",
-				cache.Level, cache.Type, cache.SizeKB, cache.Ways, cache.LineSizeBytes, cache.TotalSets), styleNormal)
 			y++
-			if y >= contentHeight {
-				break
+			if app.collapsed[category] {
+				continue
 			}
-			if y >= 2 {
-				retrotui.PrintAt(app.screen, x+8, y, fmt.Sprintf("Max Cores Sharing: %d | Max Processor IDs: %d",
-					cache.MaxCoresSharing, cache.MaxProcessorIDs), styleNormal)
+
+			colWidth, numCols := featureGridColumns(width, x+8)
+
+			// Calculate how many rows we need
+			numRows := (len(features) + numCols - 1) / numCols
+
+			names := make([]string, len(features))
+			for i, feature := range features {
+				names[i] = feature.Name
 			}
-			y++
-			if y >= 2 && y < contentHeight {
-				retrotui.PrintAt(app.screen, x+8, y, fmt.Sprintf("Write Policy: %s | Self-Init: %v | Fully Assoc: %v",
-					cache.WritePolicy, cache.SelfInitializing, cache.FullyAssociative), styleNormal)
+			app.featureGrids = append(app.featureGrids, featureGrid{
+				names: names, x: x + 8, line: y + app.scrollY - 2, colWidth: colWidth, numCols: numCols, bottom: contentHeight,
+			})
+
+			// Display features in columns row by row
+			for row := 0; row < numRows; row++ {
+				if y >= 2 && y < contentHeight {
+					for col := 0; col < numCols; col++ {
+						idx := row*numCols + col
+						if idx < len(features) {
+							colX := x + 8 + (col * colWidth)
+							app.printClipped(colX, y, truncateString(features[idx].Name, colWidth-2), styleNormal)
+						}
+					}
+				}
+				y++
 			}
 			y++
 		}
 		y += 2
 	}
 
-	// TLB Info
-	if len(app.hwInfo.CPU.TLBInfo.L1Data) > 0 || len(app.hwInfo.CPU.TLBInfo.L1Inst) > 0 || len(app.hwInfo.CPU.TLBInfo.L2Unified) > 0 {
+	// All Features (displayed in columns)
+	if len(app.hwInfo.CPU.Features) > 0 {
 		if y >= 2 && y < contentHeight {
-			app.renderSectionTitle(x, y, width, "TLB (Translation Lookaside Buffer)")
+			app.renderSectionTitle(x, y, width, fmt.Sprintf("All Supported Features (%d total)", len(app.hwInfo.CPU.Features)))
 		}
 		y++
-		if len(app.hwInfo.CPU.TLBInfo.L1Data) > 0 {
+
+		// AVX-512 extensions first, as one compact list of suffixes
+		features, avx512 := splitAVX512(app.hwInfo.CPU.Features)
+		if len(avx512) > 0 {
 			if y >= 2 && y < contentHeight {
-				retrotui.PrintAt(app.screen, x+4, y, "L1 Data TLB:", styleSection)
+				app.printClipped(x+4, y, "AVX-512:", styleSection)
 			}
 			y++
-			for _, tlb := range app.hwInfo.CPU.TLBInfo.L1Data {
-				if y >= contentHeight {
-					break
-				}
-				if y >= 2 {
-					retrotui.PrintAt(app.screen, x+8, y, fmt.Sprintf("%s: %d entries, %s associativity",
-						tlb.PageSize, tlb.Entries, tlb.Associativity), styleNormal)
+			for _, line := range wrapText(strings.Join(avx512, " "), max(width-x-12, 1)) {
+				if y >= 2 && y < contentHeight {
+					app.printClipped(x+8, y, line, styleNormal)
 				}
 				y++
 			}
 		}
-		if len(app.hwInfo.CPU.TLBInfo.L1Inst) > 0 {
+
+		colWidth, numCols := featureGridColumns(width, x+4)
+
+		// Calculate how many rows we need
+		numRows := (len(features) + numCols - 1) / numCols
+		app.featureGrids = append(app.featureGrids, featureGrid{
+			names: features, x: x + 4, line: y + app.scrollY - 2, colWidth: colWidth, numCols: numCols, bottom: contentHeight,
+		})
+
+		// Display features in columns row by row
+		for row := 0; row < numRows; row++ {
 			if y >= 2 && y < contentHeight {
-				retrotui.PrintAt(app.screen, x+4, y, "L1 Instruction TLB:", styleSection)
+				for col := 0; col < numCols; col++ {
+					idx := row*numCols + col
+					if idx < len(features) {
+						colX := x + 4 + (col * colWidth)
+						app.printClipped(colX, y, truncateString(features[idx], colWidth-2), styleNormal)
+					}
+				}
 			}
 			y++
-			for _, tlb := range app.hwInfo.CPU.TLBInfo.L1Inst {
-				if y >= contentHeight {
-					break
-				}
-				if y >= 2 {
-					retrotui.PrintAt(app.screen, x+8, y, fmt.Sprintf("%s: %d entries, %s associativity",
-						tlb.PageSize, tlb.Entries, tlb.Associativity), styleNormal)
-				}
-				y++
+		}
+	}
+
+	return y + app.scrollY - 2
+}
+
+// featureSortMode is the Features page ordering, cycled with 's'.
+type featureSortMode int
+
+const (
+	featureSortName featureSortMode = iota
+	featureSortCategory
+	featureSortVendor
+	featureSortModes // Number of modes
+)
+
+var featureSortNames = map[featureSortMode]string{
+	featureSortName:     "name",
+	featureSortCategory: "category",
+	featureSortVendor:   "vendor",
+}
+
+// filteredFeatures returns the features matching the search query, in the
+// current sort order. Category and vendor ties are broken by name.
+func (app *App) filteredFeatures() []string {
+	query := strings.ToLower(app.searchQuery)
+	matches := []string{}
+	for _, feature := range app.hwInfo.CPU.Features {
+		if strings.Contains(strings.ToLower(feature), query) {
+			matches = append(matches, feature)
+		}
+	}
+
+	sort.Strings(matches)
+	if app.featureSort != featureSortName {
+		details := featureDetailIndex(app.hwInfo.CPU.FeatureCategories)
+		key := func(name string) string {
+			if app.featureSort == featureSortVendor {
+				return details[name].Vendor
 			}
+			return details[name].Category
 		}
-		if len(app.hwInfo.CPU.TLBInfo.L2Unified) > 0 {
-			if y >= 2 && y < contentHeight {
-				retrotui.PrintAt(app.screen, x+4, y, "L2 Unified TLB:", styleSection)
+		sort.SliceStable(matches, func(i, j int) bool {
+			return key(matches[i]) < key(matches[j])
+		})
+	}
+	return matches
+}
+
+// featureListSection returns the Features page's filtered, sorted list, one
+// feature per line.
+func (app *App) featureListSection() reportSection {
+	features := app.filteredFeatures()
+	section := reportSection{Title: fmt.Sprintf("All Supported Features (%d total, by %s)", len(app.hwInfo.CPU.Features), featureSortNames[app.featureSort])}
+	if app.searchQuery != "" {
+		section.Title = fmt.Sprintf("Filter: /%s (%d of %d, by %s)", app.searchQuery, len(features), len(app.hwInfo.CPU.Features), featureSortNames[app.featureSort])
+	}
+	for _, name := range features {
+		section.add("%s", name)
+	}
+	return section
+}
+
+func (app *App) renderFeatures(width, height int) int {
+	y := 2 - app.scrollY // Start below title border
+	x := 3
+	contentHeight := height - 4 // Account for border and menu
+	features := app.filteredFeatures()
+	app.selectedFeature = max(0, min(app.selectedFeature, len(features)-1))
+
+	// Build the detail pane for the selected feature; it is pinned to the
+	// bottom of the content area and the grid scrolls above it
+	paneLines := []string{}
+	paneTitle := ""
+	if len(features) > 0 {
+		name := features[app.selectedFeature]
+		paneTitle = "Feature: " + name
+		detail, ok := featureDetailIndex(app.hwInfo.CPU.FeatureCategories)[name]
+		if ok {
+			paneLines = append(paneLines, fmt.Sprintf("Category: %s | Vendor: %s", detail.Category, detail.Vendor))
+			if detail.Description != "" {
+				paneLines = append(paneLines, wrapText(detail.Description, max(width-x-9, 1))...)
 			}
-			y++
-			for _, tlb := range app.hwInfo.CPU.TLBInfo.L2Unified {
-				if y >= contentHeight {
-					break
-				}
-				if y >= 2 {
-					retrotui.PrintAt(app.screen, x+8, y, fmt.Sprintf("%s: %d entries, %s associativity",
-						tlb.PageSize, tlb.Entries, tlb.Associativity), styleNormal)
+		} else {
+			paneLines = append(paneLines, "No description available")
+		}
+	}
+	paneTop := contentHeight - len(paneLines) - 1
+	if paneTitle == "" || paneTop < 5 {
+		// Not enough room for the pane; give the grid the full height
+		paneTitle = ""
+		paneLines = nil
+		paneTop = contentHeight
+	}
+	listBottom := paneTop
+	if paneTitle != "" {
+		listBottom-- // Blank line between the grid and the pane
+	}
+
+	sortName := featureSortNames[app.featureSort]
+	title := fmt.Sprintf("All Supported Features (%d total, by %s)", len(app.hwInfo.CPU.Features), sortName)
+	if app.searchActive || app.searchQuery != "" {
+		cursor := ""
+		if app.searchActive {
+			cursor = "_"
+		}
+		title = fmt.Sprintf("Filter: /%s%s (%d of %d, by %s)", app.searchQuery, cursor, len(features), len(app.hwInfo.CPU.Features), sortName)
+	}
+	if y >= 2 && y < listBottom {
+		app.renderSectionTitle(x, y, width, title)
+	}
+	y++
+
+	if len(features) == 0 {
+		if y >= 2 && y < listBottom {
+			app.printClipped(x+4, y, "No features match the filter", styleNormal)
+		}
+		y++
+		return y + app.scrollY - 2
+	}
+
+	colWidth, numCols := featureGridColumns(width, x+4)
+	app.featureCols = numCols
+	app.featureListHeight = listBottom - 2
+	app.featureGrids = append(app.featureGrids, featureGrid{
+		names: features, x: x + 4, line: y + app.scrollY - 2, colWidth: colWidth, numCols: numCols, bottom: listBottom,
+	})
+
+	// Display features in columns row by row
+	numRows := (len(features) + numCols - 1) / numCols
+	for row := 0; row < numRows; row++ {
+		if y >= 2 && y < listBottom {
+			for col := 0; col < numCols; col++ {
+				idx := row*numCols + col
+				if idx < len(features) {
+					style := styleNormal
+					if idx == app.selectedFeature {
+						style = styleReverse
+					}
+					colX := x + 4 + (col * colWidth)
+					app.printClipped(colX, y, truncateString(features[idx], colWidth-2), style)
 				}
-				y++
 			}
 		}
-		y += 2
+		y++
 	}
 
-	// Detailed Feature Categories - displayed in columns
-	if len(app.hwInfo.CPU.FeatureCategories) > 0 {
+	// Detail pane
+	if paneTitle != "" {
+		paneY := paneTop
+		app.renderSectionTitle(x, paneY, width, paneTitle)
+		for _, line := range paneLines {
+			paneY++
+			app.printClipped(x+4, paneY, line, styleNormal)
+		}
+	}
+
+	// The pane takes fixed rows, so count them as content for scrolling
+	return y + app.scrollY - 2 + (contentHeight - listBottom)
+}
+
+func (app *App) renderCPUID(width, height int) int {
+	y := 2 - app.scrollY // Start below title border
+	x := 3
+	contentHeight := height - 4 // Account for border and menu
+
+	y = app.renderSections(x, y, width, contentHeight, []reportSection{cpuidSection(&app.hwInfo.CPU)})
+
+	return y + app.scrollY - 2
+}
+
+func (app *App) renderRAM(width, height int) int {
+	y := 2 - app.scrollY // Start below title border
+	x := 3
+	contentHeight := height - 4 // Account for border and menu
+
+	y = app.renderSections(x, y, width, contentHeight, ramSections(&app.hwInfo.RAM))
+
+	return y + app.scrollY - 2
+}
+
+func (app *App) renderDisk(width, height int) int {
+	y := 2 - app.scrollY // Start below title border
+	x := 3
+	contentHeight := height - 4 // Account for border and menu
+
+	if y >= 2 && y < contentHeight {
+		app.renderSectionTitle(x, y, width, "Mounted Filesystems")
+	}
+	y++
+	if len(app.hwInfo.Disk.Devices) == 0 {
 		if y >= 2 && y < contentHeight {
-			app.renderSectionTitle(x, y, width, "Supported Features by Category")
+			app.printClipped(x+4, y, "Disk information unavailable", styleNormal)
 		}
 		y++
+		return y + app.scrollY - 2
+	}
 
-		// Sort category names for consistent ordering
-		categoryNames := make([]string, 0, len(app.hwInfo.CPU.FeatureCategories))
-		for category := range app.hwInfo.CPU.FeatureCategories {
-			categoryNames = append(categoryNames, category)
+	for _, disk := range app.hwInfo.Disk.Devices {
+		if y >= 2 && y < contentHeight {
+			app.printClipped(x+4, y, fmt.Sprintf("%s (%s) on %s", disk.MountPoint, disk.FSType, disk.Device), styleSection)
 		}
-		sort.Strings(categoryNames)
-
-		for _, category := range categoryNames {
-			features := app.hwInfo.CPU.FeatureCategories[category]
-			if y >= contentHeight {
-				break
-			}
-			if y >= 2 {
-				retrotui.PrintAt(app.screen, x+4, y, fmt.Sprintf("▸ %s (%d features)", category, len(features)), styleSection)
+		y++
+		if y >= 2 && y < contentHeight {
+			app.printClipped(x+8, y, fmt.Sprintf("Total: %s | Used: %s (%s) | Free: %s",
+				formatBytes(disk.TotalBytes), formatBytes(disk.UsedBytes), formatPercent(disk.UsedBytes, disk.TotalBytes),
+				formatBytes(disk.FreeBytes)), styleNormal)
+		}
+		y++
+		if disk.Health != "" {
+			if y >= 2 && y < contentHeight {
+				app.printClipped(x+8, y, "Health: "+disk.Health, healthStyle(disk.Health))
 			}
 			y++
+		}
+		y++
+	}
 
-			// Calculate column layout - max 4 columns, 30 chars wide
-			colWidth := 30
-			numCols := (width - x - 8) / colWidth
-			if numCols < 1 {
-				numCols = 1
-			}
-			if numCols > 4 {
-				numCols = 4
-			}
+	return y + app.scrollY - 2
+}
 
-			// Calculate how many rows we need
-			numRows := (len(features) + numCols - 1) / numCols
+// renderTemperature draws the package and per-core temperatures, coloring
+// readings at or above --temp-warn. It draws nothing without sensors.
+func (app *App) renderTemperature(x, y, width, contentHeight int) int {
+	cpu := app.hwInfo.CPU
+	if cpu.PackageTempC == 0 && len(cpu.CoreTemps) == 0 {
+		return y
+	}
 
-			// Display features in columns row by row
-			for row := 0; row < numRows; row++ {
-				if y >= contentHeight {
-					break
-				}
-				if y >= 2 {
-					for col := 0; col < numCols; col++ {
-						idx := row*numCols + col
-						if idx < len(features) {
-							colX := x + 8 + (col * colWidth)
-							retrotui.PrintAt(app.screen, colX, y, truncateString(features[idx].Name, colWidth-2), styleNormal)
-						}
-					}
-				}
-				y++
-			}
-			y++
+	tempStyle := func(c float64) tcell.Style {
+		if c >= tempWarnC {
+			return styleBad
 		}
-		y += 2
+		return styleNormal
 	}
 
-	// All Features (displayed in columns)
-	if len(app.hwInfo.CPU.Features) > 0 {
+	if y >= 2 && y < contentHeight {
+		app.renderSectionTitle(x, y, width, "Temperature")
+	}
+	y++
+	if cpu.PackageTempC != 0 {
 		if y >= 2 && y < contentHeight {
-			app.renderSectionTitle(x, y, width, fmt.Sprintf("All Supported Features (%d total)", len(app.hwInfo.CPU.Features)))
+			app.printClipped(x+4, y, fmt.Sprintf("Package: %.1f%sC", cpu.PackageTempC, app.glyphs.degree), tempStyle(cpu.PackageTempC))
 		}
 		y++
+	}
+
+	// Calculate column layout - max 8 columns, 18 chars wide
+	colWidth := 18
+	numCols := (width - x - 8) / colWidth
+	if numCols < 1 {
+		numCols = 1
+	}
+	if numCols > 8 {
+		numCols = 8
+	}
+	numRows := (len(cpu.CoreTemps) + numCols - 1) / numCols
 
-		// Calculate column layout - max 4 columns, 30 chars wide
-		colWidth := 30
-		numCols := (width - x - 4) / colWidth
-		if numCols < 1 {
-			numCols = 1
+	for row := 0; row < numRows; row++ {
+		if y >= 2 && y < contentHeight {
+			for col := 0; col < numCols; col++ {
+				idx := row*numCols + col
+				if idx < len(cpu.CoreTemps) {
+					core := cpu.CoreTemps[idx]
+					app.printClipped(x+4+col*colWidth, y, fmt.Sprintf("%s: %.1f%sC", core.Label, core.TempC, app.glyphs.degree), tempStyle(core.TempC))
+				}
+			}
 		}
-		if numCols > 4 {
-			numCols = 4
+		y++
+	}
+	y++
+
+	return y
+}
+
+// renderTopology draws each physical core as a box listing its sibling
+// threads, e.g. [C0: T0 T1], wrapped into as many columns as fit.
+func (app *App) renderTopology(x, y, width, contentHeight int) int {
+	info := app.hwInfo.CPU.ProcessorInfo
+	if info.CoreCount == 0 {
+		return y
+	}
+	threadsPerCore := info.ThreadPerCore
+	if threadsPerCore == 0 {
+		threadsPerCore = 1
+	}
+
+	if y >= 2 && y < contentHeight {
+		app.renderSectionTitle(x, y, width, fmt.Sprintf("Core Topology (%d cores, %d threads)", info.CoreCount, info.CoreCount*threadsPerCore))
+	}
+	y++
+
+	boxes := make([]string, info.CoreCount)
+	colWidth := 0
+	for core := uint32(0); core < info.CoreCount; core++ {
+		box := fmt.Sprintf("[C%d:", core)
+		for t := uint32(0); t < threadsPerCore; t++ {
+			box += fmt.Sprintf(" T%d", core*threadsPerCore+t)
 		}
+		boxes[core] = box + "]"
+		if len(boxes[core])+2 > colWidth {
+			colWidth = len(boxes[core]) + 2
+		}
+	}
 
-		// Calculate how many rows we need
-		numRows := (len(app.hwInfo.CPU.Features) + numCols - 1) / numCols
+	// Calculate column layout - max 8 columns, as wide as the widest box
+	numCols := (width - x - 8) / colWidth
+	if numCols < 1 {
+		numCols = 1
+	}
+	if numCols > 8 {
+		numCols = 8
+	}
+	numRows := (len(boxes) + numCols - 1) / numCols
 
-		// Display features in columns row by row
-		for row := 0; row < numRows; row++ {
-			if y >= contentHeight {
-				break
-			}
-			if y >= 2 {
-				for col := 0; col < numCols; col++ {
-					idx := row*numCols + col
-					if idx < len(app.hwInfo.CPU.Features) {
-						colX := x + 4 + (col * colWidth)
-						retrotui.PrintAt(app.screen, colX, y, truncateString(app.hwInfo.CPU.Features[idx], colWidth-2), styleNormal)
-					}
+	for row := 0; row < numRows; row++ {
+		if y >= 2 && y < contentHeight {
+			for col := 0; col < numCols; col++ {
+				idx := row*numCols + col
+				if idx < len(boxes) {
+					app.printClipped(x+4+col*colWidth, y, boxes[idx], styleNormal)
 				}
 			}
+		}
+		y++
+	}
+
+	// CPUID only reports the type of the core we're running on, so the
+	// P-core/E-core split can't be drawn per box
+	if app.hwInfo.CPU.HybridInfo.IsHybrid {
+		if y >= 2 && y < contentHeight {
+			app.printClipped(x+4, y, fmt.Sprintf("Hybrid CPU: per-core types not reported (current core: %s)", app.hwInfo.CPU.HybridInfo.CoreType), styleSection)
+		}
+		y++
+	}
+	y++
+
+	return y
+}
+
+// healthStyle colors a SMART overall-health result: good for PASSED, bad
+// for anything smartctl reported as failing, normal when it is unknown.
+func healthStyle(health string) tcell.Style {
+	switch health {
+	case diskHealthPassed:
+		return styleGood
+	case diskHealthFailed:
+		return styleBad
+	default:
+		return styleNormal
+	}
+}
+
+func (app *App) renderGPU(width, height int) int {
+	y := 2 - app.scrollY // Start below title border
+	x := 3
+	contentHeight := height - 4 // Account for border and menu
+
+	y = app.renderSections(x, y, width, contentHeight, []reportSection{gpuSection(&app.hwInfo.GPU)})
+
+	return y + app.scrollY - 2
+}
+
+func (app *App) renderNetwork(width, height int) int {
+	y := 2 - app.scrollY // Start below title border
+	x := 3
+	contentHeight := height - 4 // Account for border and menu
+
+	if y >= 2 && y < contentHeight {
+		app.renderSectionTitle(x, y, width, "Network Interfaces")
+	}
+	y++
+	if len(app.hwInfo.Network.Interfaces) == 0 {
+		if y >= 2 && y < contentHeight {
+			app.printClipped(x+4, y, "No network interfaces found", styleNormal)
+		}
+		y++
+		return y + app.scrollY - 2
+	}
+
+	for _, iface := range app.hwInfo.Network.Interfaces {
+		// Loopback is listed but de-emphasized
+		style := styleNormal
+		if iface.Loopback {
+			style = styleSection
+		}
+		if y >= 2 && y < contentHeight {
+			app.printClipped(x+4, y, fmt.Sprintf("%s (%s)", iface.Name, interfaceState(iface)), style.Bold(true))
+		}
+		y++
+		for _, line := range interfaceDetails(iface) {
+			if y >= 2 && y < contentHeight {
+				app.printClipped(x+8, y, line, style)
+			}
 			y++
 		}
+		y++
 	}
+
+	return y + app.scrollY - 2
+}
+
+func (app *App) renderPCI(width, height int) int {
+	y := 2 - app.scrollY // Start below title border
+	x := 3
+	contentHeight := height - 4 // Account for border and menu
+
+	y = app.renderSections(x, y, width, contentHeight, []reportSection{pciSection(&app.hwInfo.PCI)})
+
+	return y + app.scrollY - 2
+}
+
+func (app *App) renderUSB(width, height int) int {
+	y := 2 - app.scrollY // Start below title border
+	x := 3
+	contentHeight := height - 4 // Account for border and menu
+
+	y = app.renderSections(x, y, width, contentHeight, []reportSection{usbSection(&app.hwInfo.USB)})
+
+	return y + app.scrollY - 2
+}
+
+func (app *App) renderSensors(width, height int) int {
+	y := 2 - app.scrollY // Start below title border
+	x := 3
+	contentHeight := height - 4 // Account for border and menu
+
+	y = app.renderSections(x, y, width, contentHeight, []reportSection{sensorSection(&app.hwInfo.Sensors, app.glyphs)})
+
+	return y + app.scrollY - 2
+}
+
+func (app *App) renderSystem(width, height int) int {
+	y := 2 - app.scrollY // Start below title border
+	x := 3
+	contentHeight := height - 4 // Account for border and menu
+
+	y = app.renderSections(x, y, width, contentHeight, systemSections(&app.hwInfo.System))
+
+	return y + app.scrollY - 2
+}
+
+func (app *App) renderBattery(width, height int) int {
+	y := 2 - app.scrollY // Start below title border
+	x := 3
+	contentHeight := height - 4 // Account for border and menu
+
+	y = app.renderSections(x, y, width, contentHeight, []reportSection{batterySection(&app.hwInfo.Battery, app.glyphs)})
+
+	return y + app.scrollY - 2
 }
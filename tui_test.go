@@ -0,0 +1,154 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// newTestApp returns an App drawing hwInfo on a width x height simulation
+// screen.
+func newTestApp(t testing.TB, hwInfo *HardwareInfo, width, height int) *App {
+	t.Helper()
+	s := tcell.NewSimulationScreen("")
+	if err := s.Init(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(s.Fini)
+	s.SetSize(width, height)
+	return &App{hwInfo: hwInfo, pages: availablePages(hwInfo), screen: s, glyphs: &unicodeGlyphs}
+}
+
+func TestRegisteredPageNavigation(t *testing.T) {
+	const pageExtra Page = 100
+	registry := pages
+	t.Cleanup(func() { pages = registry })
+	pages = append(pages[:len(pages):len(pages)], pageDef{
+		id:       pageExtra,
+		name:     "Extra",
+		title:    "EXTRA",
+		render:   func(app *App, width, height int) int { return 0 },
+		sections: func(app *App) []reportSection { return nil },
+	})
+
+	app := newTestApp(t, &HardwareInfo{}, 100, 40)
+	first, last := app.pages[0].id, app.pages[len(app.pages)-1].id
+	if last != pageExtra {
+		t.Fatalf("last available page = %v, want the registered page", last)
+	}
+	beforeLast := app.pages[len(app.pages)-2].id
+
+	app.currentPage = last
+	app.nextPage()
+	if app.currentPage != first {
+		t.Errorf("nextPage from the last page = %v, want %v", app.currentPage, first)
+	}
+	app.prevPage()
+	if app.currentPage != last {
+		t.Errorf("prevPage from the first page = %v, want %v", app.currentPage, last)
+	}
+	app.prevPage()
+	if app.currentPage != beforeLast {
+		t.Errorf("prevPage from the last page = %v, want %v", app.currentPage, beforeLast)
+	}
+
+	app.noWrap = true
+	app.currentPage = last
+	app.nextPage()
+	if app.currentPage != last {
+		t.Errorf("nextPage with noWrap moved from the last page to %v", app.currentPage)
+	}
+
+	page, err := parsePage("extra")
+	if err != nil || page != pageExtra {
+		t.Errorf("parsePage(\"extra\") = %v, %v; want %v", page, err, pageExtra)
+	}
+}
+
+// TestRenderAllPages draws every page on a large screen, where the layout
+// math has the most room to go wrong, and checks that nothing panics.
+func TestRenderAllPages(t *testing.T) {
+	hwInfo, err := CollectHardwareInfo()
+	if err != nil {
+		t.Skipf("collecting hardware info: %v", err)
+	}
+	app := newTestApp(t, hwInfo, 300, 100)
+	for _, def := range app.pages {
+		t.Run(def.name, func(t *testing.T) {
+			app.currentPage = def.id
+			app.scrollY = 0
+			app.render()
+		})
+	}
+}
+
+func BenchmarkRender(b *testing.B) {
+	hwInfo, err := CollectHardwareInfo()
+	if err != nil {
+		b.Skipf("collecting hardware info: %v", err)
+	}
+	app := newTestApp(b, hwInfo, 300, 100)
+	for _, def := range app.pages {
+		b.Run(def.name, func(b *testing.B) {
+			app.currentPage = def.id
+			for i := 0; i < b.N; i++ {
+				app.render()
+			}
+		})
+	}
+}
+
+func TestMenuLayoutFitsNarrowScreen(t *testing.T) {
+	const width = 80
+	app := newTestApp(t, &HardwareInfo{}, width, 24)
+	for _, def := range app.pages {
+		app.currentPage = def.id
+		found := false
+		for _, item := range app.menuLayout(width) {
+			if item.x < 2 || item.x+len(item.label) > width-2 {
+				t.Errorf("on %s, menu label %q at column %d is clipped", def.name, item.label, item.x)
+			}
+			if page, ok := app.menuItemAt(item.x, 22, width, 24); !ok || page != item.page {
+				t.Errorf("on %s, clicking %q gives %v, %v; want %v", def.name, item.label, page, ok, item.page)
+			}
+			found = found || item.page == def.id && item.label != "<" && item.label != ">"
+		}
+		if !found {
+			t.Errorf("menu has no label for the current page %s", def.name)
+		}
+	}
+}
+
+// screenRow returns the text of row y, skipping the cells wide runes cover.
+func screenRow(s tcell.SimulationScreen, y int) string {
+	cells, width, _ := s.GetContents()
+	var b strings.Builder
+	for _, cell := range cells[y*width : (y+1)*width] {
+		b.WriteString(string(cell.Runes))
+	}
+	return b.String()
+}
+
+func TestBorderTitleWideSnapshotName(t *testing.T) {
+	tests := []struct {
+		width int
+		want  string
+	}{
+		{60, "[ HARDWARE SUMMARY - 快照-テスト.json ]"},
+		{40, "[ HARDWARE SUMMARY - 快照-テスト.... ]"},
+	}
+	for _, tt := range tests {
+		app := newTestApp(t, &HardwareInfo{}, tt.width, 20)
+		app.source = "/tmp/快照-テスト.json"
+		app.render()
+
+		top := screenRow(app.screen.(tcell.SimulationScreen), 0)
+		if !strings.Contains(top, tt.want) {
+			t.Errorf("width %d: top border = %q, want it to contain %q", tt.width, top, tt.want)
+		}
+		if !strings.HasSuffix(top, string(app.glyphs.topRight)) {
+			t.Errorf("width %d: title overran the top border: %q", tt.width, top)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// usbHubClass is the bDeviceClass of USB hubs.
+const usbHubClass = "09"
+
+// collectUSBInfo lists the USB devices in sysfs in tree order: each root hub
+// followed by the devices below it, depth first by port. Interfaces, which
+// sysfs lists alongside the devices, are skipped.
+func collectUSBInfo() (*USBInfo, error) {
+	dirs, err := filepath.Glob("/sys/bus/usb/devices/*")
+	if err != nil {
+		return nil, err
+	}
+
+	info := &USBInfo{}
+	for _, dir := range dirs {
+		name := filepath.Base(dir)
+		if strings.Contains(name, ":") {
+			continue // Interface, e.g. 1-1:1.0
+		}
+		device := USBDevice{
+			Name:         name,
+			VendorID:     readSysfsHex(filepath.Join(dir, "idVendor")),
+			ProductID:    readSysfsHex(filepath.Join(dir, "idProduct")),
+			Manufacturer: readSysfsString(filepath.Join(dir, "manufacturer")),
+			Product:      readSysfsString(filepath.Join(dir, "product")),
+			Speed:        readSysfsString(filepath.Join(dir, "speed")),
+			Hub:          readSysfsString(filepath.Join(dir, "bDeviceClass")) == usbHubClass,
+			RootHub:      strings.HasPrefix(name, "usb"),
+		}
+		device.Bus, _ = strconv.Atoi(readSysfsString(filepath.Join(dir, "busnum")))
+		device.Depth = len(usbPorts(name))
+		info.Devices = append(info.Devices, device)
+	}
+
+	sort.Slice(info.Devices, func(i, j int) bool {
+		a, b := info.Devices[i], info.Devices[j]
+		if a.Bus != b.Bus {
+			return a.Bus < b.Bus
+		}
+		pa, pb := usbPorts(a.Name), usbPorts(b.Name)
+		for k := 0; k < len(pa) && k < len(pb); k++ {
+			if pa[k] != pb[k] {
+				return pa[k] < pb[k]
+			}
+		}
+		return len(pa) < len(pb)
+	})
+	return info, nil
+}
+
+// usbPorts returns the port chain of a sysfs USB device name: "1-2.4" is
+// port 4 of the hub on port 2 of bus 1, giving [2 4]. Root hubs ("usb1")
+// have none.
+func usbPorts(name string) []int {
+	_, chain, ok := strings.Cut(name, "-")
+	if !ok {
+		return nil
+	}
+	var ports []int
+	for _, part := range strings.Split(chain, ".") {
+		port, _ := strconv.Atoi(part)
+		ports = append(ports, port)
+	}
+	return ports
+}
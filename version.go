@@ -0,0 +1,27 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var versionCmd = &cobra.Command{
+	Use:   "version",
+	Short: "Print version information",
+	Long:  "Prints the version, git commit and build date of this binary.",
+	Args:  cobra.NoArgs,
+	Run:   runVersion,
+}
+
+func init() {
+	rootCmd.AddCommand(versionCmd)
+}
+
+func runVersion(cmd *cobra.Command, args []string) {
+	fmt.Println(versionString())
+}
+
+func versionString() string {
+	return fmt.Sprintf("earhw %s (commit %s, built %s)", version, commit, date)
+}